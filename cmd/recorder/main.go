@@ -1,32 +1,225 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	primaryHTTP "go-meeting-recorder/internal/adapters/primary/http"
+	"go-meeting-recorder/internal/adapters/secondary/bolt"
+	"go-meeting-recorder/internal/adapters/secondary/fake"
 	"go-meeting-recorder/internal/adapters/secondary/ffmpeg"
+	"go-meeting-recorder/internal/adapters/secondary/ical"
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/adapters/secondary/openai"
 	"go-meeting-recorder/internal/adapters/secondary/rod"
+	"go-meeting-recorder/internal/adapters/secondary/webhook"
+	"go-meeting-recorder/internal/adapters/secondary/whisper"
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
 	"go-meeting-recorder/internal/core/services"
 )
 
 func main() {
+	// SIGINT/SIGTERM stops new work at once; workers that react to session
+	// events keep running until live sessions have been finalized
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	workers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
 	// Initialize Adapters
-	rodAdapter := rod.NewRodAutomator()
-	ffmpegAdapter := ffmpeg.NewFFmpegRecorder("./recordings")
+	// Failed joins leave their diagnostics bundle beside the recordings, so
+	// deleting a session removes it too. Join flows can be overridden with
+	// teams.yaml / meet.yaml in JOIN_FLOW_DIR without a rebuild, and UI text
+	// for other languages with JOIN_FLOW_DIR/locales/<locale>.yaml. BOT_LOCALE
+	// is the client language for sessions that don't set one. Bot profile
+	// logins live in BOT_PROFILE_DIR; guest sessions get a temporary browser
+	// profile under BROWSER_PROFILE_DIR, copied from BROWSER_PROFILE_TEMPLATE
+	// when set.
+	const recordingDir = "./recordings"
+	profileDir := getEnv("BOT_PROFILE_DIR", "./data/profiles")
+	automation := rod.Config{
+		Capture:           captureConfig(),
+		DiagnosticsDir:    recordingDir,
+		FlowDir:           os.Getenv("JOIN_FLOW_DIR"),
+		Locale:            os.Getenv("BOT_LOCALE"),
+		ProfileDir:        profileDir,
+		SessionProfileDir: os.Getenv("BROWSER_PROFILE_DIR"),
+		ProfileTemplate:   os.Getenv("BROWSER_PROFILE_TEMPLATE"),
+	}
+	platforms := services.NewPlatformRegistry(splitList(os.Getenv("JITSI_DOMAINS"))...)
+	platforms.Register(domain.PlatformTeams, rod.NewTeamsAutomator(automation))
+	platforms.Register(domain.PlatformMeet, rod.NewMeetAutomator(automation))
+	ffmpegAdapter := ffmpeg.NewFFmpegRecorder(recordingDir)
+
+	// Session Store: SESSION_STORE=memory keeps history in-process only
+	var sessionRepo ports.SessionRepository
+	var webhookOutbox ports.WebhookOutbox
+	var scheduleRepo ports.ScheduleRepository
+	var calendarRepo ports.CalendarRepository
+	var profileRepo ports.ProfileRepository
+	if getEnv("SESSION_STORE", "bolt") == "memory" {
+		sessionRepo = memory.NewSessionRepository()
+		webhookOutbox = memory.NewWebhookOutbox()
+		scheduleRepo = memory.NewScheduleRepository()
+		calendarRepo = memory.NewCalendarRepository()
+		profileRepo = memory.NewProfileRepository()
+	} else {
+		db, err := bolt.Open(getEnv("SESSION_DB_PATH", "./data/sessions.db"))
+		if err != nil {
+			log.Fatalf("Failed to open session store: %v", err)
+		}
+		defer db.Close()
+		sessionRepo = bolt.NewSessionRepository(db)
+		webhookOutbox = bolt.NewWebhookOutbox(db)
+		scheduleRepo = bolt.NewScheduleRepository(db)
+		calendarRepo = bolt.NewCalendarRepository(db)
+		profileRepo = bolt.NewProfileRepository(db)
+	}
 
 	// Initialize Service (Core)
-	recordingService := services.NewRecordingService(rodAdapter, ffmpegAdapter)
+	events := services.NewEventBus()
+	// Bot profiles (POST /profiles) let sessions join signed in
+	profiles := services.NewProfileManager(profileRepo, rod.NewIdentityStore(profileDir))
+	recordingService := services.NewRecordingService(platforms, ffmpegAdapter, sessionRepo, events, profiles)
+
+	// Webhooks: WEBHOOK_URLS receive every session's events; per-session
+	// callbackUrls are added on top. Payloads are signed with WEBHOOK_SECRET.
+	webhookSecret := os.Getenv("WEBHOOK_SECRET")
+	if webhookSecret == "" {
+		log.Println("WEBHOOK_SECRET is not set: webhooks are sent without a signature")
+	}
+	webhooks := services.NewWebhookDispatcher(
+		webhookOutbox,
+		webhook.NewHTTPSender(webhookSecret),
+		sessionRepo,
+		events,
+		splitList(os.Getenv("WEBHOOK_URLS")),
+	)
+	go webhooks.Run(workers)
+
+	// Post-recording: TRANSCRIBER=whisper (default) or fake. Minutes come from
+	// SUMMARIZER_URL (OpenAI-compatible) when set, with rule-based fallback.
+	postProcessor := services.NewPostProcessor(sessionRepo, events, newTranscriber(), newSummarizer(), services.NewRuleBasedSummarizer())
+	go postProcessor.Run(workers)
+
+	// Scheduled recordings (POST /schedules)
+	clock := services.NewSystemClock()
+	scheduler := services.NewScheduler(scheduleRepo, recordingService, platforms, events, clock)
+	go scheduler.Run(ctx)
+
+	// Calendar feeds are polled every CALENDAR_POLL_MINUTES and scheduled
+	// CALENDAR_HORIZON_DAYS ahead
+	calendars := services.NewCalendarImporter(
+		calendarRepo,
+		ical.NewReader(),
+		scheduler,
+		platforms,
+		clock,
+		time.Duration(getEnvInt("CALENDAR_POLL_MINUTES", 15))*time.Minute,
+		time.Duration(getEnvInt("CALENDAR_HORIZON_DAYS", 14))*24*time.Hour,
+	)
+	go calendars.Run(ctx)
 
 	// Initialize Driving Adapter (HTTP)
-	httpHandler := primaryHTTP.NewHandler(recordingService)
+	httpHandler := primaryHTTP.NewHandler(recordingService, scheduler, calendars, profiles)
 
 	// Setup Router (Go 1.22+ ServeMux)
 	mux := http.NewServeMux()
 	httpHandler.RegisterRoutes(mux)
 
-	log.Println("Starting server on :8081")
-	if err := http.ListenAndServe(":8081", mux); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{Addr: ":8081", Handler: mux}
+	go func() {
+		log.Println("Starting server on :8081")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down: finalizing live sessions...")
+
+	// Recordings first, so their final status still reaches SSE/WebSocket clients
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := recordingService.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Sessions did not stop cleanly: %v", err)
+	}
+	stopWorkers()
+
+	// Event streams never finish on their own, so give in-flight requests a
+	// moment and then drop whatever is left
+	httpCtx, cancelHTTP := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelHTTP()
+	if err := server.Shutdown(httpCtx); err != nil {
+		server.Close()
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// captureConfig reads CAPTURE_MODE, CAPTURE_FPS, CAPTURE_QUALITY and CAPTURE_FORMAT.
+func captureConfig() rod.CaptureConfig {
+	cfg := rod.DefaultCaptureConfig()
+	cfg.Mode = rod.CaptureMode(getEnv("CAPTURE_MODE", string(cfg.Mode)))
+	cfg.Format = domain.FrameFormat(getEnv("CAPTURE_FORMAT", string(cfg.Format)))
+	cfg.FPS = getEnvInt("CAPTURE_FPS", cfg.FPS)
+	cfg.Quality = getEnvInt("CAPTURE_QUALITY", cfg.Quality)
+	return cfg
+}
+
+func getEnvInt(key string, fallback int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// splitList parses a comma-separated env value, dropping empty entries.
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func newTranscriber() ports.Transcriber {
+	if getEnv("TRANSCRIBER", "whisper") == "fake" {
+		return fake.NewTranscriber()
+	}
+	return whisper.NewTranscriber(whisper.Config{
+		Binary:   getEnv("WHISPER_BIN", "whisper-cli"),
+		Model:    getEnv("WHISPER_MODEL", "./models/ggml-base.bin"),
+		Language: getEnv("WHISPER_LANGUAGE", "auto"),
+		Threads:  getEnvInt("WHISPER_THREADS", 0),
+	})
+}
+
+func newSummarizer() ports.Summarizer {
+	baseURL := os.Getenv("SUMMARIZER_URL")
+	if baseURL == "" {
+		return nil
 	}
+	return openai.NewSummarizer(openai.Config{
+		BaseURL: baseURL,
+		APIKey:  os.Getenv("SUMMARIZER_API_KEY"),
+		Model:   getEnv("SUMMARIZER_MODEL", "gpt-4o-mini"),
+	})
 }
@@ -22,7 +22,7 @@ if ! pactl info > /dev/null 2>&1; then
     # Don't exit yet, maybe app can run without audio
 fi
 
-# Create a virtual null sink
+# Create a virtual null sink (fallback only; each session gets its own sink at runtime)
 echo "Loading virtual sink..."
 pactl load-module module-null-sink sink_name=VirtualSink sink_properties=device.description="Virtual_Sink" || echo "Failed to load null sink"
 
@@ -5,6 +5,11 @@ go 1.22
 require (
 	github.com/go-rod/rod v0.114.0
 	github.com/google/uuid v1.3.1
+	github.com/gorilla/websocket v1.5.1
+	github.com/teambition/rrule-go v1.8.2
+	github.com/ysmood/gson v0.7.3
+	go.etcd.io/bbolt v1.3.10
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -12,6 +17,7 @@ require (
 	github.com/ysmood/fetchup v0.2.3 // indirect
 	github.com/ysmood/goob v0.4.0 // indirect
 	github.com/ysmood/got v0.34.1 // indirect
-	github.com/ysmood/gson v0.7.3 // indirect
 	github.com/ysmood/leakless v0.8.0 // indirect
+	golang.org/x/net v0.17.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
 )
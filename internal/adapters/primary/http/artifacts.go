@@ -0,0 +1,59 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+func (h *Handler) getArtifact(w http.ResponseWriter, r *http.Request) {
+	h.serveArtifact(domain.ArtifactKind(r.PathValue("kind")))(w, r)
+}
+
+// serveArtifact streams a session file. http.ServeContent handles Range,
+// If-Range, If-None-Match and If-Modified-Since, so browsers can seek and resume.
+func (h *Handler) serveArtifact(kind domain.ArtifactKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionId := r.PathValue("sessionId")
+		if sessionId == "" {
+			http.Error(w, "sessionId is required", http.StatusBadRequest)
+			return
+		}
+
+		path, err := h.service.GetArtifactPath(r.Context(), sessionId, kind)
+		if err != nil {
+			if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrArtifactNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				http.Error(w, domain.ErrArtifactNotFound.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Artifacts are immutable once written, so size+mtime is a sufficient strong validator
+		w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, info.Size(), info.ModTime().UnixNano()))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filepath.Base(path)))
+		http.ServeContent(w, r, filepath.Base(path), info.ModTime(), f)
+	}
+}
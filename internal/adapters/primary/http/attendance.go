@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// getAttendance serves per-participant presence as ?format=json (default) or csv.
+// For a meeting still in progress, present participants are counted up to now.
+func (h *Handler) getAttendance(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.GetSessionPlatform(r.Context(), r.PathValue("sessionId"))
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	end := time.Now()
+	if session.EndTime != nil {
+		end = *session.EndTime
+	}
+	records := domain.Attendance(session.Presence, end)
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			SessionID    string                    `json:"sessionId"`
+			Participants []domain.AttendanceRecord `json:"participants"`
+			Timeline     []domain.PresenceEvent    `json:"timeline"`
+		}{session.ID, records, presenceTimeline(session.Presence)})
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="attendance-%s.csv"`, session.ID))
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"name", "first_seen", "last_seen", "total_seconds"})
+		for _, rec := range records {
+			cw.Write([]string{
+				rec.Name,
+				rec.FirstSeen.UTC().Format(time.RFC3339),
+				rec.LastSeen.UTC().Format(time.RFC3339),
+				strconv.FormatInt(rec.TotalSeconds, 10),
+			})
+		}
+		cw.Flush()
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q (use json or csv)", format), http.StatusBadRequest)
+	}
+}
+
+func presenceTimeline(events []domain.PresenceEvent) []domain.PresenceEvent {
+	if events == nil {
+		return []domain.PresenceEvent{}
+	}
+	return events
+}
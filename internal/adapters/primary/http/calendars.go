@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+const maxCalendarUpload = 10 << 20
+
+// importCalendar schedules the meetings in an uploaded .ics body. Options come
+// from the query: ?participantName=&joinLeadMinutes=&callbackUrl= (repeatable).
+func (h *Handler) importCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := domain.CalendarOptions{
+		ParticipantName: q.Get("participantName"),
+		CallbackURLs:    q["callbackUrl"],
+	}
+	if v := q.Get("joinLeadMinutes"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid joinLeadMinutes %q", v), http.StatusBadRequest)
+			return
+		}
+		opts.JoinLeadMinutes = n
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCalendarUpload))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	result, err := h.calendars.ImportCalendar(r.Context(), data, opts)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(result)
+}
+
+type subscribeRequest struct {
+	URL             string   `json:"url"`
+	ParticipantName string   `json:"participantName"`
+	CallbackURLs    []string `json:"callbackUrls"`
+	JoinLeadMinutes int      `json:"joinLeadMinutes"`
+}
+
+func (h *Handler) subscribeCalendar(w http.ResponseWriter, r *http.Request) {
+	var req subscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.calendars.Subscribe(r.Context(), req.URL, domain.CalendarOptions{
+		ParticipantName: req.ParticipantName,
+		CallbackURLs:    req.CallbackURLs,
+		JoinLeadMinutes: req.JoinLeadMinutes,
+	})
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sub)
+}
+
+func (h *Handler) listCalendars(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.calendars.ListSubscriptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if subs == nil {
+		subs = []*domain.CalendarSubscription{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subs)
+}
+
+func (h *Handler) unsubscribeCalendar(w http.ResponseWriter, r *http.Request) {
+	if err := h.calendars.Unsubscribe(r.Context(), r.PathValue("calendarId")); err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCalendarError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrCalendarNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// getChat serves the meeting chat captured so far as ?format=json (default) or txt.
+// Messages arrive live, so this works while the bot is still in the meeting.
+func (h *Handler) getChat(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.GetSessionPlatform(r.Context(), r.PathValue("sessionId"))
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	messages := session.Chat
+	if messages == nil {
+		messages = []domain.ChatMessage{}
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			SessionID string               `json:"sessionId"`
+			Messages  []domain.ChatMessage `json:"messages"`
+		}{session.ID, messages})
+	case "txt":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, msg := range messages {
+			fmt.Fprintf(w, "[%s] %s: %s\n", msg.Time.UTC().Format("15:04:05"), msg.Sender, msg.Text)
+			if len(msg.Links) > 0 {
+				fmt.Fprintf(w, "    %s\n", strings.Join(msg.Links, "\n    "))
+			}
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q (use json or txt)", format), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// errorResponse is the JSON body for errors clients are expected to handle programmatically.
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Platform string `json:"platform,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, status int, body errorBody) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: body})
+}
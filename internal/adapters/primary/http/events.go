@@ -0,0 +1,166 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// keepAliveInterval stops idle proxies from closing long-lived event streams
+const keepAliveInterval = 15 * time.Second
+
+var upgrader = websocket.Upgrader{
+	// The API has no browser session to protect, so cross-origin dashboards may connect
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// streamEvents serves a session's events as Server-Sent Events. The stream
+// ends with the event, status change or error, that takes the session out of
+// its active states.
+func (h *Handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	sessionId := r.PathValue("sessionId")
+	events, err := h.service.SubscribeEvents(r.Context(), sessionId)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Checked after subscribing, so a transition in between is still seen.
+	// The check repeats on keep-alive in case the bus dropped the event.
+	finished := func() bool {
+		session, err := h.service.GetSessionPlatform(r.Context(), sessionId)
+		if err != nil {
+			return true // Deleted while streaming
+		}
+		if session.Status.IsActive() {
+			return false
+		}
+		writeSSE(w, domain.Event{
+			Type:      domain.EventStatusChanged,
+			SessionID: sessionId,
+			Time:      time.Now(),
+			Status:    session.Status,
+		})
+		flusher.Flush()
+		return true
+	}
+	if finished() {
+		return
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			writeSSE(w, event)
+			flusher.Flush()
+			if event.EndsSession() {
+				return
+			}
+		case <-keepAlive.C:
+			if finished() {
+				return
+			}
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// writeSSE writes one event. Events made up by the handler have no bus ID
+// and are sent without one, so they do not move the client's Last-Event-ID.
+func writeSSE(w io.Writer, event domain.Event) {
+	data, _ := json.Marshal(event)
+	if event.ID != 0 {
+		fmt.Fprintf(w, "id: %d\n", event.ID)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+}
+
+// streamEventsWebSocket pushes events as JSON text messages. Without a
+// ?sessionId= query parameter it subscribes to every session; with one, the
+// connection closes after the event that ends the session.
+func (h *Handler) streamEventsWebSocket(w http.ResponseWriter, r *http.Request) {
+	sessionId := r.URL.Query().Get("sessionId")
+	events, err := h.service.SubscribeEvents(r.Context(), sessionId)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("[HTTP] WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// Drain client frames so close and ping control messages are processed
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+			if sessionId != "" && event.EndsSession() {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(5*time.Second))
+				return
+			}
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
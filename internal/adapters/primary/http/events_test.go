@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// liveSession is a recording service with one session whose events the test
+// publishes by hand.
+type liveSession struct {
+	ports.RecordingService
+
+	mu     sync.Mutex
+	status domain.SessionStatus
+	events chan domain.Event
+}
+
+func (s *liveSession) SubscribeEvents(ctx context.Context, sessionId string) (<-chan domain.Event, error) {
+	return s.events, nil
+}
+
+func (s *liveSession) GetSessionPlatform(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &domain.MeetingSession{ID: sessionId, Status: s.status}, nil
+}
+
+func (s *liveSession) publish(event domain.Event) {
+	s.mu.Lock()
+	if event.Status != "" {
+		s.status = event.Status
+	}
+	s.mu.Unlock()
+	s.events <- event
+}
+
+func TestStreamEventsEndsWhenSessionFails(t *testing.T) {
+	service := &liveSession{status: domain.StatusRecording, events: make(chan domain.Event, 8)}
+	h := NewHandler(service, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/meetings/s1/events", nil)
+	req.SetPathValue("sessionId", "s1")
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		h.streamEvents(rec, req)
+		close(done)
+	}()
+
+	// An event without a status must not end the stream
+	service.publish(domain.Event{ID: 1, Type: domain.EventDurationTick, SessionID: "s1", Duration: "1m0s"})
+	service.publish(domain.Event{ID: 2, Type: domain.EventTranscriptReady, SessionID: "s1"})
+	select {
+	case <-done:
+		t.Fatal("stream ended on an event that left the session active")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	service.publish(domain.Event{ID: 3, Type: domain.EventError, SessionID: "s1", Status: domain.StatusError, Error: "Recorder failed"})
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream still open after the session failed")
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "event: "+string(domain.EventError)) || !strings.Contains(body, "Recorder failed") {
+		t.Errorf("the error event was not sent:\n%s", body)
+	}
+}
@@ -2,28 +2,83 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"go-meeting-recorder/internal/core/domain"
 	"go-meeting-recorder/internal/core/ports"
 )
 
 type Handler struct {
-	service ports.RecordingService
+	service          ports.RecordingService
+	schedules        ports.ScheduleService
+	calendars        ports.CalendarService
+	profiles         ports.ProfileService
+	sessionResources map[string]http.HandlerFunc
 }
 
-func NewHandler(service ports.RecordingService) *Handler {
-	return &Handler{service: service}
+func NewHandler(service ports.RecordingService, schedules ports.ScheduleService, calendars ports.CalendarService, profiles ports.ProfileService) *Handler {
+	return &Handler{service: service, schedules: schedules, calendars: calendars, profiles: profiles}
 }
 
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /meetings/start", h.startRecording)
 	mux.HandleFunc("POST /meetings/stop/{sessionId}", h.stopRecording)
 	mux.HandleFunc("GET /meetings/status/{sessionId}", h.getStatus)
+	mux.HandleFunc("GET /meetings", h.listSessions)
+	mux.HandleFunc("DELETE /meetings/{sessionId}", h.deleteSession)
+	mux.HandleFunc("GET /meetings/{sessionId}/artifacts/{kind}", h.getArtifact)
+	mux.HandleFunc("GET /events/ws", h.streamEventsWebSocket)
+	mux.HandleFunc("POST /schedules", h.createSchedule)
+	mux.HandleFunc("GET /schedules", h.listSchedules)
+	mux.HandleFunc("GET /schedules/{scheduleId}", h.getSchedule)
+	mux.HandleFunc("DELETE /schedules/{scheduleId}", h.cancelSchedule)
+	mux.HandleFunc("POST /calendars/import", h.importCalendar)
+	mux.HandleFunc("POST /calendars", h.subscribeCalendar)
+	mux.HandleFunc("GET /calendars", h.listCalendars)
+	mux.HandleFunc("DELETE /calendars/{calendarId}", h.unsubscribeCalendar)
+	mux.HandleFunc("POST /profiles", h.createProfile)
+	mux.HandleFunc("GET /profiles", h.listProfiles)
+	mux.HandleFunc("GET /profiles/{profileId}", h.getProfile)
+	mux.HandleFunc("DELETE /profiles/{profileId}", h.deleteProfile)
+	mux.HandleFunc("PUT /profiles/{profileId}/storage-state", h.putStorageState)
+
+	// Per-session sub-resources share a single pattern: ServeMux rejects
+	// "GET /meetings/{sessionId}/recording" as ambiguous next to
+	// "GET /meetings/status/{sessionId}" (both match /meetings/status/recording).
+	h.sessionResources = map[string]http.HandlerFunc{
+		"recording":   h.serveArtifact(domain.ArtifactRecording),
+		"audio":       h.serveArtifact(domain.ArtifactAudio),
+		"diagnostics": h.serveArtifact(domain.ArtifactDiagnostics),
+		"events":      h.streamEvents,
+		"transcript":  h.getTranscript,
+		"minutes":     h.getMinutes,
+		"chat":        h.getChat,
+		"attendance":  h.getAttendance,
+	}
+	mux.HandleFunc("GET /meetings/{sessionId}/{resource}", h.getSessionResource)
+}
+
+func (h *Handler) getSessionResource(w http.ResponseWriter, r *http.Request) {
+	handler, ok := h.sessionResources[r.PathValue("resource")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
 }
 
 type startRequest struct {
-	MeetingURL      string `json:"meetingUrl"`
-	ParticipantName string `json:"participantName"`
+	MeetingURL      string            `json:"meetingUrl"`
+	ParticipantName string            `json:"participantName"`
+	CallbackURLs    []string          `json:"callbackUrls"`
+	LobbyTimeout    int               `json:"lobbyTimeoutMinutes"`
+	StopPolicy      domain.StopPolicy `json:"stopPolicy"`
+	Locale          string            `json:"locale"`
+	ProfileID       string            `json:"profileId"`
 }
 
 func (h *Handler) startRecording(w http.ResponseWriter, r *http.Request) {
@@ -33,8 +88,37 @@ func (h *Handler) startRecording(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := h.service.StartRecording(r.Context(), req.MeetingURL, req.ParticipantName)
+	session, err := h.service.StartRecording(r.Context(), domain.StartRequest{
+		MeetingURL:          req.MeetingURL,
+		ParticipantName:     req.ParticipantName,
+		CallbackURLs:        req.CallbackURLs,
+		LobbyTimeoutMinutes: req.LobbyTimeout,
+		StopPolicy:          req.StopPolicy,
+		Locale:              req.Locale,
+		ProfileID:           req.ProfileID,
+	})
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRequest) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domain.ErrProfileSignedOut) || errors.Is(err, domain.ErrProfileBusy) {
+			code := "profile_signed_out"
+			if errors.Is(err, domain.ErrProfileBusy) {
+				code = "profile_busy"
+			}
+			writeError(w, http.StatusConflict, errorBody{Code: code, Message: err.Error()})
+			return
+		}
+		var unsupported *domain.UnsupportedMeetingError
+		if errors.As(err, &unsupported) {
+			writeError(w, http.StatusBadRequest, errorBody{
+				Code:     "unsupported_meeting_url",
+				Message:  unsupported.Error(),
+				Platform: string(unsupported.Platform),
+			})
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -76,3 +160,71 @@ func (h *Handler) getStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(session)
 }
+
+// listSessions supports ?status=&platform=&participantName=&seriesId=&from=&to= (RFC 3339, on createdAt),
+// ?sort=createdAt|startTime|endTime&order=asc|desc, and ?limit=&cursor= pagination.
+func (h *Handler) listSessions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.SessionFilter{
+		Status:          domain.SessionStatus(q.Get("status")),
+		Platform:        domain.Platform(q.Get("platform")),
+		ParticipantName: q.Get("participantName"),
+		SeriesID:        q.Get("seriesId"),
+		SortBy:          domain.SessionSortField(q.Get("sort")),
+		Descending:      q.Get("order") != "asc",
+		Cursor:          q.Get("cursor"),
+	}
+
+	for param, dst := range map[string]**time.Time{"from": &filter.CreatedFrom, "to": &filter.CreatedTo} {
+		if v := q.Get(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s: %v", param, err), http.StatusBadRequest)
+				return
+			}
+			*dst = &t
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := h.service.ListSessions(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFilter) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) deleteSession(w http.ResponseWriter, r *http.Request) {
+	sessionId := r.PathValue("sessionId")
+	if sessionId == "" {
+		http.Error(w, "sessionId is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.DeleteSession(r.Context(), sessionId)
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrSessionActive):
+		http.Error(w, "session is still active; stop it before deleting", http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
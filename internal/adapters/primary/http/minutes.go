@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// getMinutes serves the minutes as ?format=json (default) or md.
+func (h *Handler) getMinutes(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.GetSessionPlatform(r.Context(), r.PathValue("sessionId"))
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if session.MinutesStatus != domain.ProcessingDone || session.Minutes == nil {
+		status := session.MinutesStatus
+		if status == "" {
+			status = domain.ProcessingPending
+		}
+		http.Error(w, fmt.Sprintf("%v (status: %s)", domain.ErrMinutesNotReady, status), http.StatusConflict)
+		return
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(session.Minutes)
+	case "md", "markdown":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		writeMinutesMarkdown(w, session)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q (use json or md)", format), http.StatusBadRequest)
+	}
+}
+
+func writeMinutesMarkdown(w io.Writer, session *domain.MeetingSession) {
+	m := session.Minutes
+
+	fmt.Fprint(w, "# Meeting Minutes\n\n")
+	if session.StartTime != nil {
+		fmt.Fprintf(w, "- **Date:** %s\n", session.StartTime.Format(time.RFC1123))
+	}
+	if session.Duration != "" {
+		fmt.Fprintf(w, "- **Duration:** %s\n", session.Duration)
+	}
+	fmt.Fprintf(w, "- **Meeting:** %s\n\n", session.MeetingURL)
+
+	fmt.Fprintf(w, "## Summary\n\n%s\n\n", m.Summary)
+
+	fmt.Fprint(w, "## Decisions\n\n")
+	writeBullets(w, m.Decisions)
+
+	fmt.Fprint(w, "## Action Items\n\n")
+	if len(m.ActionItems) == 0 {
+		fmt.Fprint(w, "_None_\n\n")
+	} else {
+		fmt.Fprint(w, "| Action | Owner | Due |\n|---|---|---|\n")
+		for _, item := range m.ActionItems {
+			fmt.Fprintf(w, "| %s | %s | %s |\n", escapeCell(item.Description), orDash(escapeCell(item.Owner)), orDash(escapeCell(item.DueDate)))
+		}
+		fmt.Fprint(w, "\n")
+	}
+
+	fmt.Fprint(w, "## Open Questions\n\n")
+	writeBullets(w, m.OpenQuestions)
+
+	fmt.Fprintf(w, "---\n_Generated by %s at %s_\n", m.GeneratedBy, m.GeneratedAt.Format(time.RFC3339))
+}
+
+func writeBullets(w io.Writer, items []string) {
+	if len(items) == 0 {
+		fmt.Fprint(w, "_None_\n\n")
+		return
+	}
+	for _, item := range items {
+		fmt.Fprintf(w, "- %s\n", item)
+	}
+	fmt.Fprint(w, "\n")
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
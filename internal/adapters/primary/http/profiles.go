@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+const maxStorageStateUpload = 5 << 20
+
+type profileRequest struct {
+	Name     string          `json:"name"`
+	Platform domain.Platform `json:"platform"`
+}
+
+func (h *Handler) createProfile(w http.ResponseWriter, r *http.Request) {
+	var req profileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.profiles.CreateProfile(r.Context(), domain.ProfileRequest{
+		Name:     req.Name,
+		Platform: req.Platform,
+	})
+	if err != nil {
+		writeProfileError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *Handler) listProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.profiles.ListProfiles(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if profiles == nil {
+		profiles = []*domain.BotProfile{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+func (h *Handler) getProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.profiles.GetProfile(r.Context(), r.PathValue("profileId"))
+	if err != nil {
+		writeProfileError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *Handler) deleteProfile(w http.ResponseWriter, r *http.Request) {
+	if err := h.profiles.DeleteProfile(r.Context(), r.PathValue("profileId")); err != nil {
+		writeProfileError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// putStorageState replaces a profile's login with a storage state exported
+// from a signed-in browser (Playwright's storageState() format). The response
+// is the profile, never the uploaded state.
+func (h *Handler) putStorageState(w http.ResponseWriter, r *http.Request) {
+	var state domain.StorageState
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStorageStateUpload)).Decode(&state); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.profiles.UpdateStorageState(r.Context(), r.PathValue("profileId"), state)
+	if err != nil {
+		writeProfileError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+func writeProfileError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrProfileNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrProfileBusy):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+type scheduleRequest struct {
+	MeetingURL          string            `json:"meetingUrl"`
+	ParticipantName     string            `json:"participantName"`
+	CallbackURLs        []string          `json:"callbackUrls"`
+	StartTime           string            `json:"startTime"`
+	TimeZone            string            `json:"timeZone"`
+	JoinLeadMinutes     int               `json:"joinLeadMinutes"`
+	MaxDurationMinutes  int               `json:"maxDurationMinutes"`
+	LobbyTimeoutMinutes int               `json:"lobbyTimeoutMinutes"`
+	StopPolicy          domain.StopPolicy `json:"stopPolicy"`
+	Locale              string            `json:"locale"`
+	ProfileID           string            `json:"profileId"`
+}
+
+func (h *Handler) createSchedule(w http.ResponseWriter, r *http.Request) {
+	var req scheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := h.schedules.CreateSchedule(r.Context(), domain.ScheduleRequest{
+		MeetingURL:          req.MeetingURL,
+		ParticipantName:     req.ParticipantName,
+		CallbackURLs:        req.CallbackURLs,
+		StartTime:           req.StartTime,
+		TimeZone:            req.TimeZone,
+		JoinLeadMinutes:     req.JoinLeadMinutes,
+		MaxDurationMinutes:  req.MaxDurationMinutes,
+		LobbyTimeoutMinutes: req.LobbyTimeoutMinutes,
+		StopPolicy:          req.StopPolicy,
+		Locale:              req.Locale,
+		ProfileID:           req.ProfileID,
+	})
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *Handler) listSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.schedules.ListSchedules(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if schedules == nil {
+		schedules = []*domain.Schedule{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedules)
+}
+
+func (h *Handler) getSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.schedules.GetSchedule(r.Context(), r.PathValue("scheduleId"))
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// cancelSchedule drops a pending schedule; a recording it already started is stopped.
+func (h *Handler) cancelSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.schedules.CancelSchedule(r.Context(), r.PathValue("scheduleId"))
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func writeScheduleError(w http.ResponseWriter, err error) {
+	var unsupported *domain.UnsupportedMeetingError
+	switch {
+	case errors.As(err, &unsupported):
+		writeError(w, http.StatusBadRequest, errorBody{
+			Code:     "unsupported_meeting_url",
+			Message:  unsupported.Error(),
+			Platform: string(unsupported.Platform),
+		})
+	case errors.Is(err, domain.ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrScheduleNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrScheduleFinished):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
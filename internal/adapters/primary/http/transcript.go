@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// getTranscript serves the transcript as ?format=json (default), srt, vtt or txt.
+func (h *Handler) getTranscript(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.GetSessionPlatform(r.Context(), r.PathValue("sessionId"))
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if session.TranscriptStatus != domain.ProcessingDone {
+		status := session.TranscriptStatus
+		if status == "" {
+			status = domain.ProcessingPending
+		}
+		http.Error(w, fmt.Sprintf("%v (status: %s)", domain.ErrTranscriptNotReady, status), http.StatusConflict)
+		return
+	}
+
+	segments := session.Transcript
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			SessionID string                     `json:"sessionId"`
+			Segments  []domain.TranscriptSegment `json:"segments"`
+		}{session.ID, segments})
+	case "srt":
+		w.Header().Set("Content-Type", "application/x-subrip; charset=utf-8")
+		writeSRT(w, segments)
+	case "vtt":
+		w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+		writeVTT(w, segments)
+	case "txt":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		writeText(w, segments)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q (use json, srt, vtt or txt)", format), http.StatusBadRequest)
+	}
+}
+
+func writeSRT(w io.Writer, segments []domain.TranscriptSegment) {
+	for i, seg := range segments {
+		fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n", i+1,
+			formatTimestamp(seg.Start, ","), formatTimestamp(seg.End, ","), cueText(seg))
+	}
+}
+
+func writeVTT(w io.Writer, segments []domain.TranscriptSegment) {
+	fmt.Fprint(w, "WEBVTT\n\n")
+	for _, seg := range segments {
+		text := vttEscape(seg.Text)
+		if seg.Speaker != "" {
+			text = fmt.Sprintf("<v %s>%s", vttEscape(seg.Speaker), text)
+		}
+		fmt.Fprintf(w, "%s --> %s\n%s\n\n", formatTimestamp(seg.Start, "."), formatTimestamp(seg.End, "."), text)
+	}
+}
+
+// vttEscaper keeps speech from being read as cue markup; escaping ">" also
+// defuses "-->".
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func vttEscape(s string) string {
+	return vttEscaper.Replace(singleLine(s))
+}
+
+// singleLine collapses line breaks, since a blank line would end the cue.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func writeText(w io.Writer, segments []domain.TranscriptSegment) {
+	for _, seg := range segments {
+		fmt.Fprintf(w, "[%s] %s\n", formatTimestamp(seg.Start, ".")[:8], cueText(seg))
+	}
+}
+
+func cueText(seg domain.TranscriptSegment) string {
+	if seg.Speaker != "" {
+		return singleLine(seg.Speaker) + ": " + singleLine(seg.Text)
+	}
+	return singleLine(seg.Text)
+}
+
+// formatTimestamp renders HH:MM:SS<sep>mmm; SRT uses "," and WebVTT ".".
+func formatTimestamp(d time.Duration, sep string) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
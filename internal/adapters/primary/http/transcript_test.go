@@ -0,0 +1,24 @@
+package http
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+func TestWriteVTTEscapesCueText(t *testing.T) {
+	var b strings.Builder
+	writeVTT(&b, []domain.TranscriptSegment{
+		{Start: 0, End: 2 * time.Second, Speaker: "Ann <Host>", Text: "a --> b & <i>c</i>"},
+		{Start: 2 * time.Second, End: 4 * time.Second, Text: "first line\n\nsecond line"},
+	})
+
+	want := "WEBVTT\n\n" +
+		"00:00:00.000 --> 00:00:02.000\n<v Ann &lt;Host&gt;>a --&gt; b &amp; &lt;i&gt;c&lt;/i&gt;\n\n" +
+		"00:00:02.000 --> 00:00:04.000\nfirst line second line\n\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeVTT =\n%q\nwant\n%q", got, want)
+	}
+}
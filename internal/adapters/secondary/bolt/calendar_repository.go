@@ -0,0 +1,79 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type calendarRepository struct {
+	db *DB
+}
+
+func NewCalendarRepository(db *DB) ports.CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+func (r *calendarRepository) Save(ctx context.Context, sub *domain.CalendarSubscription) error {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(calendarsBucket).Put([]byte(sub.ID), data)
+	})
+}
+
+func (r *calendarRepository) Get(ctx context.Context, calendarId string) (*domain.CalendarSubscription, error) {
+	var sub *domain.CalendarSubscription
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(calendarsBucket).Get([]byte(calendarId))
+		if data == nil {
+			return domain.ErrCalendarNotFound
+		}
+		sub = &domain.CalendarSubscription{}
+		return json.Unmarshal(data, sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (r *calendarRepository) List(ctx context.Context) ([]*domain.CalendarSubscription, error) {
+	var subs []*domain.CalendarSubscription
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(calendarsBucket).ForEach(func(k, v []byte) error {
+			sub := &domain.CalendarSubscription{}
+			if err := json.Unmarshal(v, sub); err != nil {
+				return err
+			}
+			subs = append(subs, sub)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+	})
+	return subs, nil
+}
+
+func (r *calendarRepository) Delete(ctx context.Context, calendarId string) error {
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(calendarsBucket)
+		if b.Get([]byte(calendarId)) == nil {
+			return domain.ErrCalendarNotFound
+		}
+		return b.Delete([]byte(calendarId))
+	})
+}
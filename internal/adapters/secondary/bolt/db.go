@@ -0,0 +1,38 @@
+package bolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bbolt "go.etcd.io/bbolt"
+)
+
+// DB is the embedded store shared by every bolt-backed repository.
+type DB struct {
+	bolt *bbolt.DB
+}
+
+// Open opens (or creates) the database file and applies pending migrations.
+func Open(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &DB{bolt: db}, nil
+}
+
+func (d *DB) Close() error {
+	return d.bolt.Close()
+}
@@ -0,0 +1,104 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bbolt "go.etcd.io/bbolt"
+)
+
+var (
+	metaBucket      = []byte("meta")
+	sessionsBucket  = []byte("sessions")
+	outboxBucket    = []byte("webhook_outbox")
+	schedulesBucket = []byte("schedules")
+	calendarsBucket = []byte("calendars")
+	profilesBucket  = []byte("profiles")
+
+	schemaVersionKey = []byte("schema_version")
+)
+
+// migration upgrades the schema by exactly one version.
+// Migrations are append-only: never edit one that has already shipped.
+type migration struct {
+	description string
+	apply       func(tx *bbolt.Tx) error
+}
+
+var migrations = []migration{
+	{
+		description: "create sessions bucket",
+		apply: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+			return err
+		},
+	},
+	{
+		description: "create webhook outbox bucket",
+		apply: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(outboxBucket)
+			return err
+		},
+	},
+	{
+		description: "create schedules bucket",
+		apply: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(schedulesBucket)
+			return err
+		},
+	},
+	{
+		description: "create calendar subscriptions bucket",
+		apply: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(calendarsBucket)
+			return err
+		},
+	},
+	{
+		description: "create bot profiles bucket",
+		apply: func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(profilesBucket)
+			return err
+		},
+	},
+}
+
+// migrate brings the database up to len(migrations). All pending steps run in a
+// single transaction, so a failed upgrade leaves the file untouched.
+func migrate(db *bbolt.DB) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+
+		current := schemaVersion(meta)
+		if current > uint64(len(migrations)) {
+			return fmt.Errorf("database schema version %d is newer than this binary supports (%d)", current, len(migrations))
+		}
+
+		for v := current; v < uint64(len(migrations)); v++ {
+			m := migrations[v]
+			if err := m.apply(tx); err != nil {
+				return fmt.Errorf("migration %d (%s) failed: %w", v+1, m.description, err)
+			}
+			fmt.Printf("[Bolt] Applied migration %d: %s\n", v+1, m.description)
+		}
+
+		return setSchemaVersion(meta, uint64(len(migrations)))
+	})
+}
+
+func schemaVersion(meta *bbolt.Bucket) uint64 {
+	raw := meta.Get(schemaVersionKey)
+	if len(raw) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(raw)
+}
+
+func setSchemaVersion(meta *bbolt.Bucket, v uint64) error {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return meta.Put(schemaVersionKey, buf)
+}
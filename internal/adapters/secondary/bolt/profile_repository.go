@@ -0,0 +1,79 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type profileRepository struct {
+	db *DB
+}
+
+func NewProfileRepository(db *DB) ports.ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+func (r *profileRepository) Save(ctx context.Context, profile *domain.BotProfile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(profilesBucket).Put([]byte(profile.ID), data)
+	})
+}
+
+func (r *profileRepository) Get(ctx context.Context, profileId string) (*domain.BotProfile, error) {
+	var profile *domain.BotProfile
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(profilesBucket).Get([]byte(profileId))
+		if data == nil {
+			return domain.ErrProfileNotFound
+		}
+		profile = &domain.BotProfile{}
+		return json.Unmarshal(data, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (r *profileRepository) List(ctx context.Context) ([]*domain.BotProfile, error) {
+	var profiles []*domain.BotProfile
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(profilesBucket).ForEach(func(k, v []byte) error {
+			profile := &domain.BotProfile{}
+			if err := json.Unmarshal(v, profile); err != nil {
+				return err
+			}
+			profiles = append(profiles, profile)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].CreatedAt.Before(profiles[j].CreatedAt)
+	})
+	return profiles, nil
+}
+
+func (r *profileRepository) Delete(ctx context.Context, profileId string) error {
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(profilesBucket)
+		if b.Get([]byte(profileId)) == nil {
+			return domain.ErrProfileNotFound
+		}
+		return b.Delete([]byte(profileId))
+	})
+}
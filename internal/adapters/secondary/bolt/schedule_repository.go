@@ -0,0 +1,69 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type scheduleRepository struct {
+	db *DB
+}
+
+func NewScheduleRepository(db *DB) ports.ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) Save(ctx context.Context, schedule *domain.Schedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(schedulesBucket).Put([]byte(schedule.ID), data)
+	})
+}
+
+func (r *scheduleRepository) Get(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	var schedule *domain.Schedule
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(schedulesBucket).Get([]byte(scheduleId))
+		if data == nil {
+			return domain.ErrScheduleNotFound
+		}
+		schedule = &domain.Schedule{}
+		return json.Unmarshal(data, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (r *scheduleRepository) List(ctx context.Context) ([]*domain.Schedule, error) {
+	var schedules []*domain.Schedule
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(schedulesBucket).ForEach(func(k, v []byte) error {
+			schedule := &domain.Schedule{}
+			if err := json.Unmarshal(v, schedule); err != nil {
+				return err
+			}
+			schedules = append(schedules, schedule)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].StartAt.Before(schedules[j].StartAt)
+	})
+	return schedules, nil
+}
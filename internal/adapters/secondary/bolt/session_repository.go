@@ -0,0 +1,79 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type sessionRepository struct {
+	db *DB
+}
+
+func NewSessionRepository(db *DB) ports.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Save(ctx context.Context, session *domain.MeetingSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(sessionsBucket).Put([]byte(session.ID), data)
+	})
+}
+
+func (r *sessionRepository) Get(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+	var session *domain.MeetingSession
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(sessionsBucket).Get([]byte(sessionId))
+		if data == nil {
+			return domain.ErrSessionNotFound
+		}
+		session = &domain.MeetingSession{}
+		return json.Unmarshal(data, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) List(ctx context.Context) ([]*domain.MeetingSession, error) {
+	var sessions []*domain.MeetingSession
+	err := r.db.bolt.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(sessionsBucket).ForEach(func(k, v []byte) error {
+			session := &domain.MeetingSession{}
+			if err := json.Unmarshal(v, session); err != nil {
+				return err
+			}
+			sessions = append(sessions, session)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+func (r *sessionRepository) Delete(ctx context.Context, sessionId string) error {
+	return r.db.bolt.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(sessionsBucket)
+		if b.Get([]byte(sessionId)) == nil {
+			return domain.ErrSessionNotFound
+		}
+		return b.Delete([]byte(sessionId))
+	})
+}
@@ -0,0 +1,107 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type webhookOutbox struct {
+	db *DB
+}
+
+func NewWebhookOutbox(db *DB) ports.WebhookOutbox {
+	return &webhookOutbox{db: db}
+}
+
+func (o *webhookOutbox) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return o.put(delivery)
+}
+
+func (o *webhookOutbox) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return o.put(delivery)
+}
+
+func (o *webhookOutbox) put(delivery *domain.WebhookDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	return o.db.bolt.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(outboxBucket).Put([]byte(delivery.ID), data)
+	})
+}
+
+func (o *webhookOutbox) Due(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var due []*domain.WebhookDelivery
+	err := o.db.bolt.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(outboxBucket).ForEach(func(k, v []byte) error {
+			delivery := &domain.WebhookDelivery{}
+			if err := json.Unmarshal(v, delivery); err != nil {
+				return err
+			}
+			if !delivery.Dead && !delivery.NextAttempt.After(now) {
+				due = append(due, delivery)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (o *webhookOutbox) Delete(ctx context.Context, deliveryId string) error {
+	return o.db.bolt.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(outboxBucket)
+		if b.Get([]byte(deliveryId)) == nil {
+			return fmt.Errorf("delivery %s not found", deliveryId)
+		}
+		return b.Delete([]byte(deliveryId))
+	})
+}
+
+func (o *webhookOutbox) PruneDead(ctx context.Context, cutoff time.Time) (int, error) {
+	n := 0
+	err := o.db.bolt.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(outboxBucket)
+		var dead [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			delivery := &domain.WebhookDelivery{}
+			if err := json.Unmarshal(v, delivery); err != nil {
+				return err
+			}
+			if delivery.Dead && delivery.CreatedAt.Before(cutoff) {
+				dead = append(dead, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// Deleting while iterating with ForEach is not allowed
+		for _, k := range dead {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		n = len(dead)
+		return nil
+	})
+	return n, err
+}
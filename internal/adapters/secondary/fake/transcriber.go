@@ -0,0 +1,75 @@
+package fake
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// fakeSegmentLength is the span each generated segment covers.
+const fakeSegmentLength = 10 * time.Second
+
+// transcriber produces a deterministic transcript derived only from the
+// audio length, for development and tests without a speech model.
+type transcriber struct{}
+
+func NewTranscriber() ports.Transcriber {
+	return &transcriber{}
+}
+
+func (t *transcriber) Transcribe(ctx context.Context, audioPath string) ([]domain.TranscriptSegment, error) {
+	length, err := wavDuration(audioPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []domain.TranscriptSegment
+	for start, n := time.Duration(0), 1; start < length; start, n = start+fakeSegmentLength, n+1 {
+		end := start + fakeSegmentLength
+		if end > length {
+			end = length
+		}
+		segments = append(segments, domain.TranscriptSegment{
+			Start: start,
+			End:   end,
+			Text:  fmt.Sprintf("Fake transcript segment %d.", n),
+		})
+	}
+	return segments, nil
+}
+
+// wavDuration reads the byte rate from the fmt chunk, which ffmpeg always
+// writes first, and estimates length from the file size. Extra chunks
+// (e.g. LIST) skew the estimate by a few bytes at most.
+func wavDuration(path string) (time.Duration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	header := make([]byte, 44)
+	if _, err := f.Read(header); err != nil {
+		return 0, err
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return 0, fmt.Errorf("%s is not a WAV file", path)
+	}
+
+	byteRate := binary.LittleEndian.Uint32(header[28:32])
+	if byteRate == 0 {
+		return 0, fmt.Errorf("%s has an invalid byte rate", path)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	dataBytes := info.Size() - 44
+	return time.Duration(float64(dataBytes) / float64(byteRate) * float64(time.Second)), nil
+}
@@ -0,0 +1,198 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// matroskaWriter muxes timestamped still images into a streaming Matroska
+// container so ffmpeg sees each frame's real capture time. image2pipe has no
+// notion of timestamps, which forces a constant (and wrong) frame rate.
+//
+// Only the subset of EBML needed for a single video track is written: the
+// segment has unknown size and every frame goes into its own cluster.
+type matroskaWriter struct {
+	w       io.Writer
+	origin  time.Time // Timestamp 0; shared with the audio input
+	started bool
+}
+
+// Matroska element IDs
+const (
+	idEBML               = 0x1A45DFA3
+	idEBMLVersion        = 0x4286
+	idEBMLReadVersion    = 0x42F7
+	idEBMLMaxIDLength    = 0x42F2
+	idEBMLMaxSizeLength  = 0x42F3
+	idDocType            = 0x4282
+	idDocTypeVersion     = 0x4287
+	idDocTypeReadVersion = 0x4285
+	idSegment            = 0x18538067
+	idInfo               = 0x1549A966
+	idTimestampScale     = 0x2AD7B1
+	idMuxingApp          = 0x4D80
+	idWritingApp         = 0x5741
+	idTracks             = 0x1654AE6B
+	idTrackEntry         = 0xAE
+	idTrackNumber        = 0xD7
+	idTrackUID           = 0x73C5
+	idTrackType          = 0x83
+	idCodecID            = 0x86
+	idCodecPrivate       = 0x63A2
+	idVideo              = 0xE0
+	idPixelWidth         = 0xB0
+	idPixelHeight        = 0xBA
+	idCluster            = 0x1F43B675
+	idTimestamp          = 0xE7
+	idSimpleBlock        = 0xA3
+)
+
+// unknownSize marks an element whose length is not known up front (8-byte vint, all ones).
+var unknownSize = []byte{0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
+func newMatroskaWriter(w io.Writer, origin time.Time) *matroskaWriter {
+	return &matroskaWriter{w: w, origin: origin}
+}
+
+// WriteFrame writes the stream header on the first call, then one cluster per frame.
+func (m *matroskaWriter) WriteFrame(frame domain.VideoFrame) error {
+	if !m.started {
+		if err := m.writeHeader(frame); err != nil {
+			return err
+		}
+		m.started = true
+	}
+
+	ms := frame.Timestamp.Sub(m.origin).Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+
+	// SimpleBlock: track number (vint), relative timestamp (int16), flags (keyframe)
+	block := make([]byte, 0, len(frame.Data)+4)
+	block = append(block, 0x81, 0x00, 0x00, 0x80)
+	block = append(block, frame.Data...)
+
+	var cluster bytes.Buffer
+	writeUint(&cluster, idTimestamp, uint64(ms))
+	writeElement(&cluster, idSimpleBlock, block)
+
+	var out bytes.Buffer
+	writeElement(&out, idCluster, cluster.Bytes())
+	_, err := m.w.Write(out.Bytes())
+	return err
+}
+
+func (m *matroskaWriter) writeHeader(first domain.VideoFrame) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(first.Data))
+	if err != nil {
+		return fmt.Errorf("failed to read frame dimensions: %w", err)
+	}
+
+	var ebml bytes.Buffer
+	writeUint(&ebml, idEBMLVersion, 1)
+	writeUint(&ebml, idEBMLReadVersion, 1)
+	writeUint(&ebml, idEBMLMaxIDLength, 4)
+	writeUint(&ebml, idEBMLMaxSizeLength, 8)
+	writeString(&ebml, idDocType, "matroska")
+	writeUint(&ebml, idDocTypeVersion, 4)
+	writeUint(&ebml, idDocTypeReadVersion, 2)
+
+	var info bytes.Buffer
+	writeUint(&info, idTimestampScale, uint64(time.Millisecond))
+	writeString(&info, idMuxingApp, "go-meeting-recorder")
+	writeString(&info, idWritingApp, "go-meeting-recorder")
+
+	var video bytes.Buffer
+	writeUint(&video, idPixelWidth, uint64(cfg.Width))
+	writeUint(&video, idPixelHeight, uint64(cfg.Height))
+
+	var track bytes.Buffer
+	writeUint(&track, idTrackNumber, 1)
+	writeUint(&track, idTrackUID, 1)
+	writeUint(&track, idTrackType, 1) // video
+	switch first.Format {
+	case domain.FrameFormatPNG:
+		// No native Matroska ID for PNG; wrap it in a VfW header with the MPNG FourCC
+		writeString(&track, idCodecID, "V_MS/VFW/FOURCC")
+		writeElement(&track, idCodecPrivate, bitmapInfoHeader(cfg.Width, cfg.Height, "MPNG"))
+	default:
+		writeString(&track, idCodecID, "V_MJPEG")
+	}
+	writeElement(&track, idVideo, video.Bytes())
+
+	var tracks bytes.Buffer
+	writeElement(&tracks, idTrackEntry, track.Bytes())
+
+	var out bytes.Buffer
+	writeElement(&out, idEBML, ebml.Bytes())
+	writeID(&out, idSegment)
+	out.Write(unknownSize)
+	writeElement(&out, idInfo, info.Bytes())
+	writeElement(&out, idTracks, tracks.Bytes())
+
+	_, err = m.w.Write(out.Bytes())
+	return err
+}
+
+// bitmapInfoHeader builds the 40-byte BITMAPINFOHEADER used as VfW CodecPrivate.
+func bitmapInfoHeader(width, height int, fourcc string) []byte {
+	buf := make([]byte, 40)
+	binary.LittleEndian.PutUint32(buf[0:], 40)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(width))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(height))
+	binary.LittleEndian.PutUint16(buf[12:], 1)  // planes
+	binary.LittleEndian.PutUint16(buf[14:], 24) // bit count
+	copy(buf[16:20], fourcc)
+	return buf
+}
+
+func writeID(buf *bytes.Buffer, id uint32) {
+	switch {
+	case id > 0xFFFFFF:
+		buf.Write([]byte{byte(id >> 24), byte(id >> 16), byte(id >> 8), byte(id)})
+	case id > 0xFFFF:
+		buf.Write([]byte{byte(id >> 16), byte(id >> 8), byte(id)})
+	case id > 0xFF:
+		buf.Write([]byte{byte(id >> 8), byte(id)})
+	default:
+		buf.WriteByte(byte(id))
+	}
+}
+
+// writeSize encodes n as an 8-byte EBML vint. Always using the widest form
+// wastes a few bytes but keeps the encoder trivial.
+func writeSize(buf *bytes.Buffer, n uint64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	b[0] = 0x01
+	buf.Write(b)
+}
+
+func writeElement(buf *bytes.Buffer, id uint32, data []byte) {
+	writeID(buf, id)
+	writeSize(buf, uint64(len(data)))
+	buf.Write(data)
+}
+
+func writeUint(buf *bytes.Buffer, id uint32, v uint64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	i := 0
+	for i < 7 && b[i] == 0 {
+		i++
+	}
+	writeElement(buf, id, b[i:])
+}
+
+func writeString(buf *bytes.Buffer, id uint32, s string) {
+	writeElement(buf, id, []byte(s))
+}
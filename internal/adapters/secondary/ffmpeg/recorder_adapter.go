@@ -7,17 +7,27 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
+	"go-meeting-recorder/internal/core/domain"
 	"go-meeting-recorder/internal/core/ports"
 )
 
+// Raw PCM layout of the audio stream handed to Start (see pulse.Sink.Capture).
+const (
+	audioSampleFormat = "s16le"
+	audioSampleRate   = 48000
+	audioChannels     = 2
+)
+
 type ffmpegRecorder struct {
 	recordingDir string
 	cmds         map[string]*exec.Cmd
-	audioCmds    map[string]*exec.Cmd // Store audio processes
 	stdins       map[string]io.WriteCloser
+	audioPipes   map[string]io.WriteCloser // Write end of the audio input (pipe:3)
+	artifacts    map[string]domain.Artifacts
 	mu           sync.Mutex
 }
 
@@ -26,115 +36,200 @@ func NewFFmpegRecorder(recordingDir string) ports.MediaRecorder {
 	return &ffmpegRecorder{
 		recordingDir: recordingDir,
 		cmds:         make(map[string]*exec.Cmd),
-		audioCmds:    make(map[string]*exec.Cmd),
 		stdins:       make(map[string]io.WriteCloser),
+		audioPipes:   make(map[string]io.WriteCloser),
+		artifacts:    make(map[string]domain.Artifacts),
 	}
 }
 
-func (f *ffmpegRecorder) Start(ctx context.Context, sessionId string, videoStream io.Reader, audioStream io.Reader) error {
+func (f *ffmpegRecorder) Start(ctx context.Context, sessionId string, videoFrames <-chan domain.VideoFrame, audioStream io.Reader) error {
 	filename := fmt.Sprintf("meeting-%s-%d.mp4", sessionId, time.Now().Unix())
 	path := filepath.Join(f.recordingDir, filename)
+	audioFilename := fmt.Sprintf("meeting-%s-audio.wav", sessionId)
+	audioPath := filepath.Join(f.recordingDir, audioFilename)
 
-	// 1. Video Recording (From Pipe)
-	videoCmd := exec.Command("ffmpeg",
+	// Input 0: Video frames (stdin), muxed as Matroska so each frame
+	// keeps its capture timestamp relative to origin
+	origin := time.Now()
+	args := []string{
 		"-y",
-		"-f", "image2pipe", "-vcodec", "png", "-r", "5", "-i", "-",
+		"-thread_queue_size", "512",
+		"-f", "matroska", "-i", "pipe:0",
+	}
+
+	// Input 1: Session audio (pipe:3, first ExtraFile)
+	var audioR, audioW *os.File
+	if audioStream != nil {
+		var err error
+		audioR, audioW, err = os.Pipe()
+		if err != nil {
+			return err
+		}
+		args = append(args,
+			"-thread_queue_size", "512",
+			"-f", audioSampleFormat, "-ar", strconv.Itoa(audioSampleRate), "-ac", strconv.Itoa(audioChannels),
+			"-i", "pipe:3",
+		)
+	}
+
+	// Output 1: Muxed MP4. VFR keeps source timestamps so playback speed matches wall-clock time.
+	args = append(args, "-map", "0:v",
 		"-c:v", "libx264", "-pix_fmt", "yuv420p", "-preset", "ultrafast",
-		path,
+		"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2", "-vsync", "vfr",
 	)
+	if audioStream != nil {
+		args = append(args, "-map", "1:a", "-c:a", "aac", "-b:a", "128k", "-af", "aresample=async=1")
+	}
+	args = append(args, "-movflags", "+faststart", path)
+
+	// Output 2: Audio only WAV (kept for transcription and archiving)
+	if audioStream != nil {
+		args = append(args, "-map", "1:a", "-c:a", "pcm_s16le", audioPath)
+	}
+
+	cmd := exec.Command("ffmpeg", args...)
+	if audioR != nil {
+		cmd.ExtraFiles = []*os.File{audioR}
+	}
 
-	videoStdin, err := videoCmd.StdinPipe()
+	videoStdin, err := cmd.StdinPipe()
 	if err != nil {
+		closeFiles(audioR, audioW)
 		return err
 	}
 
-	// 2. Audio Recording (PulseAudio System Capture)
-	// Records from default output (what the bot "hears")
-	audioFilename := fmt.Sprintf("meeting-%s-audio.wav", sessionId)
-	audioPath := filepath.Join(f.recordingDir, audioFilename)
-	
-	audioCmd := exec.Command("ffmpeg",
-		"-y",
-		"-f", "pulse", "-i", "default",
-		"-ac", "2",
-		audioPath,
-	)
+	if err := cmd.Start(); err != nil {
+		closeFiles(audioR, audioW)
+		return err
+	}
+	// The child holds its own copy of the read end
+	closeFiles(audioR)
 
-	// Start Audio Process
-	if err := audioCmd.Start(); err != nil {
-		fmt.Printf("[FFmpeg] Failed to start audio recording: %v\n", err)
-		// Proceed with video only if audio fails
-	} else {
-		fmt.Printf("[FFmpeg] Started audio recording: %s\n", audioPath)
+	artifacts := domain.Artifacts{domain.ArtifactRecording: path}
+	if audioStream != nil {
+		artifacts[domain.ArtifactAudio] = audioPath
 	}
 
 	f.mu.Lock()
-	f.cmds[sessionId] = videoCmd
-	f.audioCmds[sessionId] = audioCmd
+	f.cmds[sessionId] = cmd
 	f.stdins[sessionId] = videoStdin
+	if audioW != nil {
+		f.audioPipes[sessionId] = audioW
+	}
+	f.artifacts[sessionId] = artifacts
 	f.mu.Unlock()
 
-	if err := videoCmd.Start(); err != nil {
-		return err
+	if audioStream != nil {
+		fmt.Printf("[FFmpeg] Started A/V recording session %s to %s (audio: %s)\n", sessionId, path, audioPath)
+	} else {
+		fmt.Printf("[FFmpeg] Started video-only recording session %s to %s\n", sessionId, path)
 	}
 
-	fmt.Printf("[FFmpeg] Started video recording session %s to %s\n", sessionId, path)
-
 	// Pump Video
-	if videoStream != nil {
+	if videoFrames != nil {
 		go func() {
 			defer videoStdin.Close()
-			io.Copy(videoStdin, videoStream)
+			mkv := newMatroskaWriter(videoStdin, origin)
+			for frame := range videoFrames {
+				if err := mkv.WriteFrame(frame); err != nil {
+					fmt.Printf("[FFmpeg] Video pipe closed for session %s: %v\n", sessionId, err)
+					return
+				}
+			}
 		}()
 	} else {
 		videoStdin.Close()
 	}
 
+	// Pump Audio
+	if audioStream != nil {
+		go func() {
+			defer audioW.Close()
+			io.Copy(audioW, audioStream)
+		}()
+	}
+
 	return nil
 }
 
-func (f *ffmpegRecorder) Stop(ctx context.Context, sessionId string) (string, error) {
+func (f *ffmpegRecorder) Stop(ctx context.Context, sessionId string) (domain.Artifacts, error) {
 	f.mu.Lock()
-	videoCmd, ok := f.cmds[sessionId]
-	audioCmd := f.audioCmds[sessionId]
+	cmd, ok := f.cmds[sessionId]
 	stdin := f.stdins[sessionId]
+	audioPipe := f.audioPipes[sessionId]
+	artifacts := f.artifacts[sessionId]
 	f.mu.Unlock()
 
 	if !ok {
-		return "", fmt.Errorf("no active recording for session %s", sessionId)
+		return nil, fmt.Errorf("no active recording for session %s", sessionId)
 	}
 
 	fmt.Printf("[FFmpeg] Stopping recording for session %s\n", sessionId)
 
-	// Stop Video: Close stdin to signal EOF
+	// Close both inputs to signal EOF; ffmpeg then finalizes every output
 	if stdin != nil {
 		stdin.Close()
 	}
-	// Wait for video finish
-	err := videoCmd.Wait()
-
-	// Stop Audio: Process must be killed (SIGTERM)
-	if audioCmd != nil && audioCmd.Process != nil {
-		fmt.Println("[FFmpeg] Stopping audio process...")
-		_ = audioCmd.Process.Signal(os.Interrupt)
-		// Give it a moment to finalize file headers
-		done := make(chan error)
-		go func() { done <- audioCmd.Wait() }()
-		
-		select {
-		case <-done:
-			// Process exited clean-ish
-		case <-time.After(2 * time.Second):
-			// Force kill if stuck
-			_ = audioCmd.Process.Kill()
-		}
+	if audioPipe != nil {
+		audioPipe.Close()
+	}
+	// ffmpeg normally exits once it has flushed; kill it if ctx runs out first
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	var err error
+	select {
+	case err = <-exited:
+	case <-ctx.Done():
+		fmt.Printf("[FFmpeg] Session %s did not finalize in time, killing ffmpeg\n", sessionId)
+		cmd.Process.Kill()
+		<-exited
+		err = fmt.Errorf("ffmpeg did not finalize: %w", ctx.Err())
 	}
 
 	f.mu.Lock()
 	delete(f.cmds, sessionId)
-	delete(f.audioCmds, sessionId)
 	delete(f.stdins, sessionId)
+	delete(f.audioPipes, sessionId)
+	delete(f.artifacts, sessionId)
+	f.mu.Unlock()
+
+	// Only report files ffmpeg actually managed to write
+	for kind, path := range artifacts {
+		if _, statErr := os.Stat(path); statErr != nil {
+			delete(artifacts, kind)
+		}
+	}
+
+	return artifacts, err
+}
+
+func (f *ffmpegRecorder) DeleteArtifacts(ctx context.Context, sessionId string) error {
+	f.mu.Lock()
+	_, recording := f.cmds[sessionId]
 	f.mu.Unlock()
 
-	return "check recordings directory", err
+	if recording {
+		return fmt.Errorf("session %s is still recording", sessionId)
+	}
+
+	// Every artifact is named meeting-<sessionId>-*
+	matches, err := filepath.Glob(filepath.Join(f.recordingDir, fmt.Sprintf("meeting-%s-*", sessionId)))
+	if err != nil {
+		return err
+	}
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		fmt.Printf("[FFmpeg] Deleted %s\n", path)
+	}
+	return nil
+}
+
+func closeFiles(files ...*os.File) {
+	for _, file := range files {
+		if file != nil {
+			file.Close()
+		}
+	}
 }
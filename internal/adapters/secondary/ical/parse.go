@@ -0,0 +1,260 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// property is one content line: NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// vevent holds the VEVENT properties the importer uses.
+type vevent struct {
+	uid          string
+	summary      string
+	location     string
+	description  string
+	url          string
+	status       string
+	start        time.Time
+	end          time.Time
+	duration     time.Duration
+	allDay       bool
+	rrule        string
+	rdates       []time.Time
+	exdates      []time.Time
+	recurrenceID *time.Time
+}
+
+// parse reads every VEVENT in the document. Nested components such as
+// VALARM are skipped, as are VTIMEZONE blocks: TZIDs resolve through the
+// Go time zone database (see location). A VEVENT with a malformed line is
+// logged and left out rather than failing the whole document.
+func parse(data []byte) ([]*vevent, error) {
+	lines, err := unfold(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*vevent
+	var current *vevent
+	var broken error // First error in the current VEVENT
+	nested := 0
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			if current != nil && broken == nil {
+				broken = fmt.Errorf("line %d: %w", i+1, err)
+			}
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && prop.value == "VEVENT":
+			current = &vevent{}
+			broken = nil
+			nested = 0
+			continue
+		case prop.name == "END" && prop.value == "VEVENT":
+			switch {
+			case current == nil:
+			case broken != nil:
+				log.Printf("[ICal] Skipping event %q: %v", current.uid, broken)
+			case current.uid != "" && !current.start.IsZero():
+				events = append(events, current)
+			}
+			current = nil
+			continue
+		case current == nil:
+			continue
+		case prop.name == "BEGIN":
+			nested++
+			continue
+		case prop.name == "END":
+			nested--
+			continue
+		case nested > 0:
+			continue
+		}
+
+		if err := current.set(prop); err != nil && broken == nil {
+			broken = fmt.Errorf("line %d (%s): %w", i+1, prop.name, err)
+		}
+	}
+	return events, nil
+}
+
+func (e *vevent) set(prop property) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		e.uid = prop.value
+	case "SUMMARY":
+		e.summary = unescape(prop.value)
+	case "LOCATION":
+		e.location = unescape(prop.value)
+	case "DESCRIPTION":
+		e.description = unescape(prop.value)
+	case "URL", "X-MICROSOFT-SKYPETEAMSMEETINGURL", "X-GOOGLE-CONFERENCE":
+		if e.url == "" {
+			e.url = prop.value
+		}
+	case "STATUS":
+		e.status = strings.ToUpper(prop.value)
+	case "DTSTART":
+		e.allDay = prop.params["VALUE"] == "DATE" || len(prop.value) == 8
+		e.start, err = parseTime(prop)
+	case "DTEND":
+		e.end, err = parseTime(prop)
+	case "DURATION":
+		e.duration, err = parseDuration(prop.value)
+	case "RRULE":
+		e.rrule = prop.value
+	case "RDATE":
+		var times []time.Time
+		times, err = parseTimes(prop)
+		e.rdates = append(e.rdates, times...)
+	case "EXDATE":
+		var times []time.Time
+		times, err = parseTimes(prop)
+		e.exdates = append(e.exdates, times...)
+	case "RECURRENCE-ID":
+		var t time.Time
+		if t, err = parseTime(prop); err == nil {
+			e.recurrenceID = &t
+		}
+	}
+	return err
+}
+
+// maxLineLength bounds one unfolded line; longer ones fail the document
+// rather than silently cutting it short.
+const maxLineLength = 1 << 20
+
+// unfold joins continuation lines (RFC 5545 §3.1).
+func unfold(data []byte) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading calendar: %w", err)
+	}
+	return lines, nil
+}
+
+func parseLine(line string) (property, error) {
+	// The value starts at the first colon outside a quoted parameter value
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			prop.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return prop, nil
+}
+
+var textEscapes = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescape(s string) string {
+	return textEscapes.Replace(s)
+}
+
+func parseTimes(prop property) ([]time.Time, error) {
+	var times []time.Time
+	for _, v := range strings.Split(prop.value, ",") {
+		t, err := parseTime(property{name: prop.name, params: prop.params, value: v})
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// parseTime handles UTC ("...Z"), zoned (TZID=) and floating date-times, and
+// plain dates. Floating times are read as UTC.
+func parseTime(prop property) (time.Time, error) {
+	value := strings.TrimSpace(prop.value)
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	loc := location(prop.params["TZID"])
+	if len(value) == 8 {
+		return time.ParseInLocation("20060102", value, loc)
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
+
+// parseDuration reads the RFC 5545 DURATION subset calendars emit for
+// meetings, e.g. PT1H30M or P1D.
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+	var d time.Duration
+	inTime := false
+	num := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num = num*10 + int(r-'0')
+		case r == 'T':
+			inTime = true
+		case r == 'W':
+			d += time.Duration(num) * 7 * 24 * time.Hour
+			num = 0
+		case r == 'D':
+			d += time.Duration(num) * 24 * time.Hour
+			num = 0
+		case r == 'H' && inTime:
+			d += time.Duration(num) * time.Hour
+			num = 0
+		case r == 'M' && inTime:
+			d += time.Duration(num) * time.Minute
+			num = 0
+		case r == 'S' && inTime:
+			d += time.Duration(num) * time.Second
+			num = 0
+		default:
+			return 0, fmt.Errorf("unsupported duration %q", value)
+		}
+	}
+	return d, nil
+}
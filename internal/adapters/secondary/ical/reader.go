@@ -0,0 +1,178 @@
+package ical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+const (
+	maxFeedSize     = 10 << 20
+	defaultDuration = time.Hour // For events with neither DTEND nor DURATION
+)
+
+type reader struct {
+	client *http.Client
+}
+
+func NewReader() ports.CalendarReader {
+	return &reader{client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (r *reader) Fetch(ctx context.Context, url string) ([]byte, error) {
+	// webcal:// is how calendar apps advertise subscribable feeds
+	if rest, ok := strings.CutPrefix(url, "webcal://"); ok {
+		url = "https://" + rest
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("calendar feed responded %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFeedSize {
+		return nil, fmt.Errorf("calendar feed exceeds %d bytes", maxFeedSize)
+	}
+	return data, nil
+}
+
+func (r *reader) Expand(data []byte, from, to time.Time) ([]domain.CalendarEvent, error) {
+	events, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Modified instances of a series arrive as extra VEVENTs carrying the
+	// series UID and the RECURRENCE-ID of the instance they replace.
+	// They usually only repeat what changed, so the rest comes from the master.
+	overridden := make(map[string][]time.Time)
+	masters := make(map[string]*vevent)
+	for _, e := range events {
+		if e.recurrenceID != nil {
+			overridden[e.uid] = append(overridden[e.uid], *e.recurrenceID)
+		} else {
+			masters[e.uid] = e
+		}
+	}
+	for _, e := range events {
+		if master, ok := masters[e.uid]; ok && e.recurrenceID != nil {
+			e.inherit(master)
+		}
+	}
+
+	var out []domain.CalendarEvent
+	for _, e := range events {
+		if e.allDay || e.status == "CANCELLED" {
+			continue
+		}
+		starts, err := e.occurrences(overridden[e.uid], from, to)
+		if err != nil {
+			log.Printf("[ICal] Skipping event %q: %v", e.uid, err)
+			continue
+		}
+		for _, start := range starts {
+			out = append(out, e.at(start))
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out, nil
+}
+
+// occurrences lists the event's start times in [from, to).
+func (e *vevent) occurrences(overridden []time.Time, from, to time.Time) ([]time.Time, error) {
+	if e.rrule == "" || e.recurrenceID != nil {
+		var starts []time.Time
+		for _, t := range append([]time.Time{e.start}, e.rdates...) {
+			if !t.Before(from) && t.Before(to) {
+				starts = append(starts, t)
+			}
+		}
+		return starts, nil
+	}
+
+	opt, err := rrule.StrToROptionInLocation(e.rrule, e.start.Location())
+	if err != nil {
+		return nil, fmt.Errorf("invalid RRULE: %w", err)
+	}
+	opt.Dtstart = e.start
+	rule, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RRULE: %w", err)
+	}
+
+	set := &rrule.Set{}
+	set.RRule(rule)
+	for _, t := range e.rdates {
+		set.RDate(t)
+	}
+	for _, t := range append(e.exdates, overridden...) {
+		set.ExDate(t)
+	}
+	starts := set.Between(from, to, true)
+	// Between is inclusive at both ends; the window is half-open
+	if n := len(starts); n > 0 && starts[n-1].Equal(to) {
+		starts = starts[:n-1]
+	}
+	return starts, nil
+}
+
+func (e *vevent) inherit(master *vevent) {
+	if e.summary == "" {
+		e.summary = master.summary
+	}
+	if e.location == "" {
+		e.location = master.location
+	}
+	if e.description == "" {
+		e.description = master.description
+	}
+	if e.url == "" {
+		e.url = master.url
+	}
+}
+
+func (e *vevent) at(start time.Time) domain.CalendarEvent {
+	length := defaultDuration
+	switch {
+	case !e.end.IsZero() && e.end.After(e.start):
+		length = e.end.Sub(e.start)
+	case e.duration > 0:
+		length = e.duration
+	}
+	return domain.CalendarEvent{
+		UID:         e.uid,
+		Summary:     e.summary,
+		Location:    e.location,
+		Description: e.description,
+		URL:         e.url,
+		Start:       start,
+		End:         start.Add(length),
+		TimeZone:    e.start.Location().String(),
+	}
+}
@@ -0,0 +1,225 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// calendar wraps VEVENT lines in a VCALENDAR with CRLF line endings.
+func calendar(lines ...string) []byte {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...)
+	all = append(all, "END:VCALENDAR", "")
+	return []byte(strings.Join(all, "\r\n"))
+}
+
+type occurrence struct {
+	uid, summary, url string
+	start, end        string // RFC 3339
+	timeZone          string
+}
+
+func TestExpand(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name string
+		ics  []byte
+		want []occurrence
+	}{
+		{
+			name: "folded lines",
+			ics: calendar(
+				"BEGIN:VEVENT",
+				"UID:folded",
+				"SUMMARY:Quarterly planning with the",
+				"  whole team",
+				"URL:https://meet.google.com/abc-",
+				"\tdefg-hij",
+				"DTSTART:20260302T100000Z",
+				"DTEND:20260302T110000Z",
+				"END:VEVENT",
+			),
+			want: []occurrence{
+				{"folded", "Quarterly planning with the whole team", "https://meet.google.com/abc-defg-hij", "2026-03-02T10:00:00Z", "2026-03-02T11:00:00Z", "UTC"},
+			},
+		},
+		{
+			name: "TZID with VTIMEZONE",
+			ics: calendar(
+				"BEGIN:VTIMEZONE",
+				"TZID:W. Europe Standard Time",
+				"BEGIN:STANDARD",
+				"DTSTART:16010101T030000",
+				"TZOFFSETFROM:+0200",
+				"TZOFFSETTO:+0100",
+				"END:STANDARD",
+				"END:VTIMEZONE",
+				"BEGIN:VEVENT",
+				"UID:windows",
+				"SUMMARY:Standup",
+				"DTSTART;TZID=W. Europe Standard Time:20260303T090000",
+				"DTEND;TZID=W. Europe Standard Time:20260303T091500",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:iana",
+				"SUMMARY:Sync",
+				"DTSTART;TZID=\"America/New_York\":20260304T090000",
+				"DURATION:PT30M",
+				"END:VEVENT",
+			),
+			want: []occurrence{
+				{"windows", "Standup", "", "2026-03-03T08:00:00Z", "2026-03-03T08:15:00Z", "Europe/Berlin"},
+				{"iana", "Sync", "", "2026-03-04T14:00:00Z", "2026-03-04T14:30:00Z", "America/New_York"},
+			},
+		},
+		{
+			name: "all-day events are skipped",
+			ics: calendar(
+				"BEGIN:VEVENT",
+				"UID:holiday",
+				"SUMMARY:Company holiday",
+				"DTSTART;VALUE=DATE:20260303",
+				"DTEND;VALUE=DATE:20260304",
+				"END:VEVENT",
+			),
+		},
+		{
+			name: "RRULE with EXDATE and RECURRENCE-ID",
+			ics: calendar(
+				"BEGIN:VEVENT",
+				"UID:weekly",
+				"SUMMARY:Daily",
+				"URL:https://meet.google.com/abc-defg-hij",
+				"DTSTART:20260302T100000Z",
+				"DTEND:20260302T103000Z",
+				"RRULE:FREQ=DAILY;COUNT=4",
+				"EXDATE:20260303T100000Z",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:weekly",
+				"RECURRENCE-ID:20260304T100000Z",
+				"SUMMARY:Daily (moved)",
+				"DTSTART:20260304T140000Z",
+				"DTEND:20260304T143000Z",
+				"END:VEVENT",
+			),
+			want: []occurrence{
+				{"weekly", "Daily", "https://meet.google.com/abc-defg-hij", "2026-03-02T10:00:00Z", "2026-03-02T10:30:00Z", "UTC"},
+				{"weekly", "Daily (moved)", "https://meet.google.com/abc-defg-hij", "2026-03-04T14:00:00Z", "2026-03-04T14:30:00Z", "UTC"},
+				{"weekly", "Daily", "https://meet.google.com/abc-defg-hij", "2026-03-05T10:00:00Z", "2026-03-05T10:30:00Z", "UTC"},
+			},
+		},
+		{
+			name: "cancelled events and instances are skipped",
+			ics: calendar(
+				"BEGIN:VEVENT",
+				"UID:cancelled",
+				"SUMMARY:Called off",
+				"STATUS:CANCELLED",
+				"DTSTART:20260302T100000Z",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:series",
+				"SUMMARY:Review",
+				"DTSTART:20260302T150000Z",
+				"RRULE:FREQ=DAILY;COUNT=2",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:series",
+				"RECURRENCE-ID:20260303T150000Z",
+				"STATUS:CANCELLED",
+				"DTSTART:20260303T150000Z",
+				"END:VEVENT",
+			),
+			want: []occurrence{
+				{"series", "Review", "", "2026-03-02T15:00:00Z", "2026-03-02T16:00:00Z", "UTC"},
+			},
+		},
+		{
+			name: "event without a meeting URL",
+			ics: calendar(
+				"BEGIN:VEVENT",
+				"UID:offline",
+				"SUMMARY:Lunch",
+				"LOCATION:Cafeteria",
+				"DTSTART:20260302T120000Z",
+				"DTEND:20260302T130000Z",
+				"END:VEVENT",
+			),
+			want: []occurrence{
+				{"offline", "Lunch", "", "2026-03-02T12:00:00Z", "2026-03-02T13:00:00Z", "UTC"},
+			},
+		},
+		{
+			name: "malformed event is skipped",
+			ics: calendar(
+				"BEGIN:VEVENT",
+				"UID:broken-time",
+				"DTSTART:tomorrow",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:broken-line",
+				"DTSTART:20260302T090000Z",
+				"not a property",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:broken-rule",
+				"DTSTART:20260302T090000Z",
+				"RRULE:FREQ=SOMETIMES",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:fine",
+				"SUMMARY:Still imported",
+				"DTSTART:20260302T090000Z",
+				"END:VEVENT",
+			),
+			want: []occurrence{
+				{"fine", "Still imported", "", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "UTC"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := NewReader().Expand(tt.ics, from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []occurrence
+			for _, e := range events {
+				got = append(got, occurrence{
+					uid:      e.UID,
+					summary:  e.Summary,
+					url:      e.URL,
+					start:    e.Start.UTC().Format(time.RFC3339),
+					end:      e.End.UTC().Format(time.RFC3339),
+					timeZone: e.TimeZone,
+				})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("event %d:\n got %+v\nwant %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpandRejectsOverlongLine(t *testing.T) {
+	ics := calendar(
+		"BEGIN:VEVENT",
+		"UID:huge",
+		"DESCRIPTION:"+strings.Repeat("x", maxLineLength),
+		"DTSTART:20260302T100000Z",
+		"END:VEVENT",
+	)
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := NewReader().Expand(ics, from, from.AddDate(0, 0, 7)); err == nil {
+		t.Fatal("expected an error for a line over the length limit")
+	}
+}
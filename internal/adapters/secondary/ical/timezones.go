@@ -0,0 +1,69 @@
+package ical
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// windowsZones maps the Windows time zone names Outlook and Teams put in
+// TZID to IANA names. Only zones commonly seen in invites are listed.
+var windowsZones = map[string]string{
+	"UTC":                             "UTC",
+	"GMT Standard Time":               "Europe/London",
+	"Greenwich Standard Time":         "Atlantic/Reykjavik",
+	"W. Europe Standard Time":         "Europe/Berlin",
+	"Central Europe Standard Time":    "Europe/Budapest",
+	"Central European Standard Time":  "Europe/Warsaw",
+	"Romance Standard Time":           "Europe/Paris",
+	"E. Europe Standard Time":         "Europe/Chisinau",
+	"FLE Standard Time":               "Europe/Kiev",
+	"GTB Standard Time":               "Europe/Bucharest",
+	"Russian Standard Time":           "Europe/Moscow",
+	"Turkey Standard Time":            "Europe/Istanbul",
+	"Israel Standard Time":            "Asia/Jerusalem",
+	"South Africa Standard Time":      "Africa/Johannesburg",
+	"Arabian Standard Time":           "Asia/Dubai",
+	"India Standard Time":             "Asia/Kolkata",
+	"SE Asia Standard Time":           "Asia/Bangkok",
+	"China Standard Time":             "Asia/Shanghai",
+	"Singapore Standard Time":         "Asia/Singapore",
+	"Tokyo Standard Time":             "Asia/Tokyo",
+	"Korea Standard Time":             "Asia/Seoul",
+	"AUS Eastern Standard Time":       "Australia/Sydney",
+	"New Zealand Standard Time":       "Pacific/Auckland",
+	"Eastern Standard Time":           "America/New_York",
+	"Central Standard Time":           "America/Chicago",
+	"Mountain Standard Time":          "America/Denver",
+	"US Mountain Standard Time":       "America/Phoenix",
+	"Pacific Standard Time":           "America/Los_Angeles",
+	"Alaskan Standard Time":           "America/Anchorage",
+	"Hawaiian Standard Time":          "Pacific/Honolulu",
+	"Atlantic Standard Time":          "America/Halifax",
+	"E. South America Standard Time":  "America/Sao_Paulo",
+	"Argentina Standard Time":         "America/Buenos_Aires",
+	"Central Standard Time (Mexico)":  "America/Mexico_City",
+	"Canada Central Standard Time":    "America/Regina",
+	"Pacific SA Standard Time":        "America/Santiago",
+	"SA Pacific Standard Time":        "America/Bogota",
+	"Egypt Standard Time":             "Africa/Cairo",
+	"W. Central Africa Standard Time": "Africa/Lagos",
+}
+
+// location resolves a TZID, falling back to UTC for names Go does not know.
+func location(tzid string) *time.Location {
+	tzid = strings.TrimPrefix(strings.TrimSpace(tzid), "/")
+	if tzid == "" {
+		return time.UTC
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc
+	}
+	if name, ok := windowsZones[tzid]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	log.Printf("[ICal] Unknown time zone %q, reading times as UTC", tzid)
+	return time.UTC
+}
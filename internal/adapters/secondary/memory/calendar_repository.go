@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// calendarRepository keeps subscriptions in process memory. They are lost on restart.
+type calendarRepository struct {
+	subs map[string]domain.CalendarSubscription
+	mu   sync.RWMutex
+}
+
+func NewCalendarRepository() ports.CalendarRepository {
+	return &calendarRepository{
+		subs: make(map[string]domain.CalendarSubscription),
+	}
+}
+
+func (r *calendarRepository) Save(ctx context.Context, sub *domain.CalendarSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subs[sub.ID] = *sub
+	return nil
+}
+
+func (r *calendarRepository) Get(ctx context.Context, calendarId string) (*domain.CalendarSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, ok := r.subs[calendarId]
+	if !ok {
+		return nil, domain.ErrCalendarNotFound
+	}
+	return &sub, nil
+}
+
+func (r *calendarRepository) List(ctx context.Context) ([]*domain.CalendarSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subs := make([]*domain.CalendarSubscription, 0, len(r.subs))
+	for _, s := range r.subs {
+		sub := s
+		subs = append(subs, &sub)
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+	})
+	return subs, nil
+}
+
+func (r *calendarRepository) Delete(ctx context.Context, calendarId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[calendarId]; !ok {
+		return domain.ErrCalendarNotFound
+	}
+	delete(r.subs, calendarId)
+	return nil
+}
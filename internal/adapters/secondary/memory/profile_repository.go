@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// profileRepository keeps bot profiles in process memory. They are lost on restart.
+type profileRepository struct {
+	profiles map[string]domain.BotProfile
+	mu       sync.RWMutex
+}
+
+func NewProfileRepository() ports.ProfileRepository {
+	return &profileRepository{
+		profiles: make(map[string]domain.BotProfile),
+	}
+}
+
+func (r *profileRepository) Save(ctx context.Context, profile *domain.BotProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[profile.ID] = *profile
+	return nil
+}
+
+func (r *profileRepository) Get(ctx context.Context, profileId string) (*domain.BotProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, ok := r.profiles[profileId]
+	if !ok {
+		return nil, domain.ErrProfileNotFound
+	}
+	return &profile, nil
+}
+
+func (r *profileRepository) List(ctx context.Context) ([]*domain.BotProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles := make([]*domain.BotProfile, 0, len(r.profiles))
+	for _, p := range r.profiles {
+		profile := p
+		profiles = append(profiles, &profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].CreatedAt.Before(profiles[j].CreatedAt)
+	})
+	return profiles, nil
+}
+
+func (r *profileRepository) Delete(ctx context.Context, profileId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.profiles[profileId]; !ok {
+		return domain.ErrProfileNotFound
+	}
+	delete(r.profiles, profileId)
+	return nil
+}
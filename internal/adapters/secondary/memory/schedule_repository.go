@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// scheduleRepository keeps schedules in process memory. They are lost on restart.
+type scheduleRepository struct {
+	schedules map[string]domain.Schedule
+	mu        sync.RWMutex
+}
+
+func NewScheduleRepository() ports.ScheduleRepository {
+	return &scheduleRepository{
+		schedules: make(map[string]domain.Schedule),
+	}
+}
+
+func (r *scheduleRepository) Save(ctx context.Context, schedule *domain.Schedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedules[schedule.ID] = *schedule
+	return nil
+}
+
+func (r *scheduleRepository) Get(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, ok := r.schedules[scheduleId]
+	if !ok {
+		return nil, domain.ErrScheduleNotFound
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) List(ctx context.Context) ([]*domain.Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedules := make([]*domain.Schedule, 0, len(r.schedules))
+	for _, s := range r.schedules {
+		schedule := s
+		schedules = append(schedules, &schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].StartAt.Before(schedules[j].StartAt)
+	})
+	return schedules, nil
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// sessionRepository keeps sessions in process memory. History is lost on restart.
+// Sessions are copied in and out, so callers never share slices or maps with
+// the stored version.
+type sessionRepository struct {
+	sessions map[string]*domain.MeetingSession
+	mu       sync.RWMutex
+}
+
+func NewSessionRepository() ports.SessionRepository {
+	return &sessionRepository{
+		sessions: make(map[string]*domain.MeetingSession),
+	}
+}
+
+func (r *sessionRepository) Save(ctx context.Context, session *domain.MeetingSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = cloneSession(session)
+	return nil
+}
+
+func (r *sessionRepository) Get(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionId]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	return cloneSession(session), nil
+}
+
+func (r *sessionRepository) List(ctx context.Context) ([]*domain.MeetingSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := make([]*domain.MeetingSession, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		sessions = append(sessions, cloneSession(session))
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+func (r *sessionRepository) Delete(ctx context.Context, sessionId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[sessionId]; !ok {
+		return domain.ErrSessionNotFound
+	}
+	delete(r.sessions, sessionId)
+	return nil
+}
+
+// cloneSession copies a session deeply enough that writes to either copy,
+// including appends to its timelines, never reach the other.
+func cloneSession(session *domain.MeetingSession) *domain.MeetingSession {
+	c := *session
+	c.CallbackURLs = slices.Clone(session.CallbackURLs)
+	c.StartTime = cloneTime(session.StartTime)
+	c.EndTime = cloneTime(session.EndTime)
+	c.Artifacts = maps.Clone(session.Artifacts)
+	c.Transcript = slices.Clone(session.Transcript)
+	c.Captions = slices.Clone(session.Captions)
+	c.Presence = slices.Clone(session.Presence)
+	if session.Chat != nil {
+		c.Chat = make([]domain.ChatMessage, len(session.Chat))
+		for i, msg := range session.Chat {
+			msg.Links = slices.Clone(msg.Links)
+			c.Chat[i] = msg
+		}
+	}
+	if session.Minutes != nil {
+		minutes := *session.Minutes
+		minutes.Decisions = slices.Clone(minutes.Decisions)
+		minutes.ActionItems = slices.Clone(minutes.ActionItems)
+		minutes.OpenQuestions = slices.Clone(minutes.OpenQuestions)
+		c.Minutes = &minutes
+	}
+	return &c
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
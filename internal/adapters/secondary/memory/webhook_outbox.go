@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// webhookOutbox keeps pending deliveries in process memory. They are lost on restart.
+type webhookOutbox struct {
+	deliveries map[string]domain.WebhookDelivery
+	mu         sync.Mutex
+}
+
+func NewWebhookOutbox() ports.WebhookOutbox {
+	return &webhookOutbox{
+		deliveries: make(map[string]domain.WebhookDelivery),
+	}
+}
+
+func (o *webhookOutbox) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return o.Update(ctx, delivery)
+}
+
+func (o *webhookOutbox) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (o *webhookOutbox) Due(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var due []*domain.WebhookDelivery
+	for _, d := range o.deliveries {
+		if !d.Dead && !d.NextAttempt.After(now) {
+			delivery := d
+			due = append(due, &delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (o *webhookOutbox) Delete(ctx context.Context, deliveryId string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.deliveries[deliveryId]; !ok {
+		return fmt.Errorf("delivery %s not found", deliveryId)
+	}
+	delete(o.deliveries, deliveryId)
+	return nil
+}
+
+func (o *webhookOutbox) PruneDead(ctx context.Context, cutoff time.Time) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	n := 0
+	for id, d := range o.deliveries {
+		if d.Dead && d.CreatedAt.Before(cutoff) {
+			delete(o.deliveries, id)
+			n++
+		}
+	}
+	return n, nil
+}
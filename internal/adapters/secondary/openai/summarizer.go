@@ -0,0 +1,158 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type Config struct {
+	BaseURL string // e.g. "http://localhost:8080/v1" for a llama.cpp server
+	APIKey  string // Optional for local servers
+	Model   string
+	Timeout time.Duration
+}
+
+// summarizer calls any OpenAI-compatible /chat/completions endpoint.
+type summarizer struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewSummarizer(cfg Config) ports.Summarizer {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Minute
+	}
+	return &summarizer{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+const systemPrompt = `You write meeting minutes from a transcript and the meeting chat.
+Reply with a single JSON object and nothing else, using exactly these keys:
+{"summary": string, "decisions": [string], "actionItems": [{"description": string, "owner": string, "dueDate": string}], "openQuestions": [string]}
+Use empty strings for unknown owners or due dates. Do not invent facts that are not in the transcript or chat.`
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (s *summarizer) Name() string { return "openai:" + s.cfg.Model }
+
+func (s *summarizer) Summarize(ctx context.Context, input domain.MinutesInput) (*domain.Minutes, error) {
+	body, err := json.Marshal(chatRequest{
+		Model: s.cfg.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt(input)},
+		},
+		Temperature:    0.2,
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimRight(s.cfg.BaseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("completion endpoint responded %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse completion response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return nil, fmt.Errorf("completion response has no choices")
+	}
+
+	minutes := &domain.Minutes{}
+	content := stripCodeFence(parsed.Choices[0].Message.Content)
+	if err := json.Unmarshal([]byte(content), minutes); err != nil {
+		return nil, fmt.Errorf("model did not return valid minutes JSON: %w", err)
+	}
+	return minutes, nil
+}
+
+func userPrompt(input domain.MinutesInput) string {
+	var b strings.Builder
+	if input.StartTime != nil {
+		fmt.Fprintf(&b, "Meeting started: %s\n", input.StartTime.Format(time.RFC1123))
+	}
+	if input.Duration != "" {
+		fmt.Fprintf(&b, "Duration: %s\n", input.Duration)
+	}
+	b.WriteString("\nTranscript:\n")
+	for _, seg := range input.Transcript {
+		speaker := seg.Speaker
+		if speaker == "" {
+			speaker = "Unknown"
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s\n", seg.Start.Round(time.Second), speaker, seg.Text)
+	}
+	if len(input.Chat) > 0 {
+		b.WriteString("\nChat:\n")
+		for _, msg := range input.Chat {
+			fmt.Fprintf(&b, "[%s] %s: %s", msg.Time.Format("15:04"), msg.Sender, msg.Text)
+			if len(msg.Links) > 0 {
+				fmt.Fprintf(&b, " (links: %s)", strings.Join(msg.Links, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// stripCodeFence tolerates models that wrap JSON in ```json fences despite instructions.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimSuffix(s, "```")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,154 @@
+package pulse
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Raw PCM format produced by Capture. Consumers of the audio stream
+// (the ffmpeg adapter) must decode it with the same parameters.
+const (
+	SampleFormat = "s16le"
+	SampleRate   = 48000
+	Channels     = 2
+)
+
+// sinkPrefix starts the name of every sink this service creates.
+const sinkPrefix = "meeting_"
+
+var sweepOnce sync.Once
+
+// Sink is a PulseAudio null sink dedicated to one session. Chrome plays into
+// it and its monitor source carries only that session's meeting audio.
+type Sink struct {
+	Name        string
+	moduleIndex string
+
+	mu      sync.Mutex
+	capture *exec.Cmd
+}
+
+// CreateSink loads a module-null-sink named after the session.
+func CreateSink(sessionId string) (*Sink, error) {
+	name := sinkPrefix + strings.ReplaceAll(sessionId, "-", "")
+	out, err := exec.Command("pactl", "load-module", "module-null-sink",
+		"sink_name="+name,
+		"sink_properties=device.description="+name,
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create null sink %s: %w", name, err)
+	}
+
+	fmt.Printf("[Pulse] Created sink %s\n", name)
+	return &Sink{
+		Name:        name,
+		moduleIndex: strings.TrimSpace(string(out)),
+	}, nil
+}
+
+// Env returns the environment for a process whose audio output should go to this sink.
+func (s *Sink) Env() []string {
+	return append(os.Environ(), "PULSE_SINK="+s.Name)
+}
+
+// Capture starts recording the sink's monitor and returns the raw PCM stream.
+// Only one capture per sink is supported.
+func (s *Sink) Capture() (io.Reader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.capture != nil {
+		return nil, fmt.Errorf("sink %s is already being captured", s.Name)
+	}
+
+	cmd := exec.Command("parec",
+		"--raw",
+		"-d", s.Name+".monitor",
+		fmt.Sprintf("--format=%s", SampleFormat),
+		fmt.Sprintf("--rate=%d", SampleRate),
+		fmt.Sprintf("--channels=%d", Channels),
+		"--latency-msec=20",
+	)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start parec on %s.monitor: %w", s.Name, err)
+	}
+
+	s.capture = cmd
+	fmt.Printf("[Pulse] Capturing %s.monitor\n", s.Name)
+	return stdout, nil
+}
+
+// Remove stops any running capture and unloads the sink module.
+func (s *Sink) Remove() error {
+	s.mu.Lock()
+	cmd := s.capture
+	s.capture = nil
+	s.mu.Unlock()
+
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Signal(os.Interrupt)
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			_ = cmd.Process.Kill()
+			<-done
+		}
+	}
+
+	if err := exec.Command("pactl", "unload-module", s.moduleIndex).Run(); err != nil {
+		return fmt.Errorf("failed to unload sink %s: %w", s.Name, err)
+	}
+	fmt.Printf("[Pulse] Removed sink %s\n", s.Name)
+	return nil
+}
+
+// RemoveStaleSinks unloads the sinks a crashed process left behind. It must
+// run before any session creates a sink; later calls do nothing.
+func RemoveStaleSinks() {
+	sweepOnce.Do(removeStaleSinks)
+}
+
+func removeStaleSinks() {
+	out, err := exec.Command("pactl", "list", "short", "modules").Output()
+	if err != nil {
+		fmt.Printf("[Pulse] Could not list modules to remove stale sinks: %v\n", err)
+		return
+	}
+	// Each line is: index, module name, arguments, tab-separated
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 || fields[1] != "module-null-sink" {
+			continue
+		}
+		name := sinkName(fields[2])
+		if !strings.HasPrefix(name, sinkPrefix) {
+			continue
+		}
+		if err := exec.Command("pactl", "unload-module", fields[0]).Run(); err != nil {
+			fmt.Printf("[Pulse] Failed to remove stale sink %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("[Pulse] Removed stale sink %s\n", name)
+	}
+}
+
+// sinkName extracts sink_name from module-null-sink arguments.
+func sinkName(args string) string {
+	for _, arg := range strings.Fields(args) {
+		if name, ok := strings.CutPrefix(arg, "sink_name="); ok {
+			return name
+		}
+	}
+	return ""
+}
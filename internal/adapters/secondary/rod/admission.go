@@ -0,0 +1,65 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// admission is what a flow reads off the page after asking to join.
+type admission string
+
+const (
+	admissionPending   admission = "pending" // Still connecting, or a screen we don't recognize
+	admissionLobby     admission = "lobby"
+	admissionInMeeting admission = "in_meeting"
+	admissionDenied    admission = "denied"
+)
+
+const admissionPoll = 2 * time.Second
+
+// awaitAdmission polls the flow until the bot is in the meeting, is refused,
+// or the session's lobby timeout runs out. The observer hears about the lobby
+// so the session can report that it is waiting.
+func (r *RodAdapter) awaitAdmission(ctx context.Context, session *domain.MeetingSession, locale string, page *rod.Page, diag *diagnostics) error {
+	wait := session.LobbyWait()
+	deadline := time.Now().Add(wait)
+	inLobby := false
+	var last admission
+
+	for {
+		state, err := r.flow.Admission(page, locale)
+		if err == nil {
+			if state != last {
+				last = state
+				diag.screenshot(page, "admission-"+string(state))
+			}
+			switch state {
+			case admissionInMeeting:
+				fmt.Printf("[Rod] Admitted to %s meeting for session %s\n", r.flow.Name(), session.ID)
+				return nil
+			case admissionDenied:
+				return fmt.Errorf("%s: %w", r.flow.Name(), domain.ErrAdmissionDenied)
+			case admissionLobby:
+				if !inLobby {
+					inLobby = true
+					fmt.Printf("[Rod] Session %s is waiting in the %s lobby\n", session.ID, r.flow.Name())
+					if observer := r.getObserver(); observer != nil {
+						observer.LobbyEntered(session.ID)
+					}
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: waited %s", domain.ErrLobbyTimeout, wait)
+		}
+		if err := sleep(ctx, admissionPoll); err != nil {
+			return err
+		}
+	}
+}
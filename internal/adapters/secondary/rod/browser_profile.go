@@ -0,0 +1,154 @@
+package rod
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod/lib/launcher"
+)
+
+// Guest sessions each get a throwaway Chrome profile so concurrent browsers
+// neither contend for the profile lock nor share cookies. The directory is
+// named <flow>-<session id> under the session profile dir and removed once
+// the browser has exited; whatever a crashed process left behind is swept
+// when the adapter starts.
+
+const browserExitTimeout = 10 * time.Second
+
+// sessionProfile is a guest session's temporary profile and the browser using it.
+type sessionProfile struct {
+	dir      string
+	launcher *launcher.Launcher
+}
+
+// chromeLockFiles mark a profile as open in a running Chrome.
+var chromeLockFiles = []string{"SingletonLock", "SingletonSocket", "SingletonCookie"}
+
+func (r *RodAdapter) sessionProfilePath(sessionID string) string {
+	return filepath.Join(r.sessionProfiles, strings.ToLower(r.flow.Name())+"-"+sessionID)
+}
+
+// createSessionProfile makes the session's profile directory, seeded from
+// the template profile when one is configured.
+func (r *RodAdapter) createSessionProfile(sessionID string) (string, error) {
+	dir := r.sessionProfilePath(sessionID)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if r.profileTemplate == "" {
+		return dir, os.MkdirAll(dir, 0700)
+	}
+	if err := copyProfile(r.profileTemplate, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to seed profile from %s: %w", r.profileTemplate, err)
+	}
+	return dir, nil
+}
+
+// sweepSessionProfiles removes this flow's session profiles left by an
+// earlier run. It must run before the adapter starts any session.
+func (r *RodAdapter) sweepSessionProfiles() {
+	entries, err := os.ReadDir(r.sessionProfiles)
+	if err != nil {
+		return
+	}
+	prefix := strings.ToLower(r.flow.Name()) + "-"
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		dir := filepath.Join(r.sessionProfiles, entry.Name())
+		if err := os.RemoveAll(dir); err != nil {
+			log.Printf("[Rod] Failed to remove stale profile %s: %v", dir, err)
+			continue
+		}
+		fmt.Printf("[Rod] Removed stale browser profile %s\n", dir)
+	}
+}
+
+// discard deletes the profile once its browser has exited, killing the
+// browser if it does not exit on its own.
+func (p sessionProfile) discard() {
+	l, dir := p.launcher, p.dir
+	exited := make(chan struct{})
+	go func() {
+		l.Cleanup() // Waits for the process, then removes its user data dir
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		return
+	case <-time.After(browserExitTimeout):
+	}
+	l.Kill()
+	select {
+	case <-exited:
+		return
+	case <-time.After(browserExitTimeout):
+		// The process never started or will not die; the files can still go
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		log.Printf("[Rod] Failed to remove browser profile %s: %v", dir, err)
+	}
+}
+
+// clearProfileLock removes the lock a crashed Chrome leaves in a persistent
+// profile. Callers must know no other browser has the profile open.
+func clearProfileLock(dir string) {
+	for _, name := range chromeLockFiles {
+		os.Remove(filepath.Join(dir, name))
+	}
+}
+
+// copyProfile copies a template profile, leaving out locks and caches that
+// only slow the copy down.
+func copyProfile(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			if rel != "." && strings.HasSuffix(d.Name(), "Cache") {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0700)
+		}
+		if !d.Type().IsRegular() {
+			return nil // Lock symlinks and sockets
+		}
+		for _, name := range chromeLockFiles {
+			if d.Name() == name {
+				return nil
+			}
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
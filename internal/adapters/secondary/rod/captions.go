@@ -0,0 +1,89 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/ysmood/gson"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// captionFlow is implemented by flows whose platform renders live captions in the DOM.
+type captionFlow interface {
+	// EnableCaptions performs one step towards turning captions on and
+	// reports whether they are now visible. Menu text is matched in locale.
+	EnableCaptions(page *rod.Page, locale string) (bool, error)
+	// CaptionWatcherJS installs a watcher that calls emit({speaker, text, start, end})
+	// once per finalized caption line. Times are epoch milliseconds.
+	CaptionWatcherJS() string
+}
+
+const captionEnableAttempts = 15
+
+// startCaptions turns captions on and forwards each finalized line to the observer.
+func (r *RodAdapter) startCaptions(ctx context.Context, sessionID, locale string, page *rod.Page, flow captionFlow) {
+	enabled, err := retryStep(ctx, captionEnableAttempts, func() (bool, error) {
+		return flow.EnableCaptions(page, locale)
+	})
+	if err != nil {
+		log.Printf("[RodCaptions] Session %s: %v", sessionID, err)
+		return
+	}
+	if !enabled {
+		log.Printf("[RodCaptions] Could not enable live captions for session %s", sessionID)
+		return
+	}
+
+	const binding = "__meetingMinutesCaption"
+	_, err = page.Expose(binding, func(line gson.JSON) (interface{}, error) {
+		observer := r.getObserver()
+		if observer == nil {
+			return nil, nil
+		}
+		text := strings.TrimSpace(line.Get("text").Str())
+		if text == "" {
+			return nil, nil
+		}
+		observer.CaptionReceived(sessionID, domain.Caption{
+			Speaker: strings.TrimSpace(line.Get("speaker").Str()),
+			Text:    text,
+			Start:   time.UnixMilli(int64(line.Get("start").Num())),
+			End:     time.UnixMilli(int64(line.Get("end").Num())),
+		})
+		return nil, nil
+	})
+	if err != nil {
+		log.Printf("[RodCaptions] Failed to expose caption binding for session %s: %v", sessionID, err)
+		return
+	}
+
+	err = rod.Try(func() {
+		page.MustEval(fmt.Sprintf(`() => (%s)(window.%s)`, flow.CaptionWatcherJS(), binding))
+	})
+	if err != nil {
+		log.Printf("[RodCaptions] Failed to install caption watcher for session %s: %v", sessionID, err)
+		return
+	}
+	fmt.Printf("[RodCaptions] Capturing live captions for session %s\n", sessionID)
+}
+
+// retryStep calls step once a second until it reports done, fails, or runs out of attempts.
+func retryStep(ctx context.Context, attempts int, step func() (bool, error)) (bool, error) {
+	for i := 0; i < attempts; i++ {
+		done, err := step()
+		if err != nil || done {
+			return done, err
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,156 @@
+package rod
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+type CaptureMode string
+
+const (
+	// CaptureScreencast pushes frames from Chrome's compositor (Page.startScreencast)
+	CaptureScreencast CaptureMode = "screencast"
+	// CaptureScreenshot polls full screenshots on a ticker (legacy, CPU heavy)
+	CaptureScreenshot CaptureMode = "screenshot"
+)
+
+type CaptureConfig struct {
+	Mode    CaptureMode
+	FPS     int                // Upper bound; screencast only emits frames when the page repaints
+	Quality int                // 0-100, JPEG only
+	Format  domain.FrameFormat // jpeg or png
+}
+
+func DefaultCaptureConfig() CaptureConfig {
+	return CaptureConfig{
+		Mode:    CaptureScreencast,
+		FPS:     15,
+		Quality: 80,
+		Format:  domain.FrameFormatJPEG,
+	}
+}
+
+// frameBuffer bounds how far capture may run ahead of the encoder.
+// Frames are dropped rather than stalling the CDP event loop.
+const frameBuffer = 30
+
+func (c CaptureConfig) interval() time.Duration {
+	if c.FPS <= 0 {
+		return time.Second / 5
+	}
+	return time.Second / time.Duration(c.FPS)
+}
+
+// captureScreencast streams compositor frames, throttled to cfg.FPS.
+func captureScreencast(ctx context.Context, page *rod.Page, cfg CaptureConfig, stop <-chan struct{}) <-chan domain.VideoFrame {
+	frames := make(chan domain.VideoFrame, frameBuffer)
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := page.Context(ctx)
+
+	minGap := cfg.interval()
+	var last time.Time
+
+	wait := p.EachEvent(func(e *proto.PageScreencastFrame) {
+		// Chrome stops sending frames until each one is acknowledged
+		_ = proto.PageScreencastFrameAck{SessionID: e.SessionID}.Call(p)
+
+		ts := time.Now()
+		if e.Metadata != nil && e.Metadata.Timestamp > 0 {
+			ts = e.Metadata.Timestamp.Time()
+		}
+		if !last.IsZero() && ts.Sub(last) < minGap {
+			return
+		}
+		last = ts
+
+		select {
+		case frames <- domain.VideoFrame{Data: e.Data, Format: cfg.Format, Timestamp: ts}:
+		default:
+			log.Printf("[RodStream] Encoder is behind, dropping frame")
+		}
+	})
+
+	req := proto.PageStartScreencast{Format: proto.PageStartScreencastFormat(cfg.Format)}
+	if cfg.Format == domain.FrameFormatJPEG {
+		req.Quality = &cfg.Quality
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	go func() {
+		// The handler may still be sending until wait returns, so frames
+		// closes only after it has
+		defer close(frames)
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		if err := req.Call(p); err != nil {
+			log.Printf("[RodStream] Failed to start screencast: %v", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		case <-done:
+		}
+
+		_ = proto.PageStopScreencast{}.Call(page)
+	}()
+
+	return frames
+}
+
+// captureScreenshots polls the page at cfg.FPS.
+func captureScreenshots(ctx context.Context, page *rod.Page, cfg CaptureConfig, stop <-chan struct{}) <-chan domain.VideoFrame {
+	frames := make(chan domain.VideoFrame, frameBuffer)
+
+	req := &proto.PageCaptureScreenshot{Format: proto.PageCaptureScreenshotFormat(cfg.Format)}
+	if cfg.Format == domain.FrameFormatJPEG {
+		req.Quality = &cfg.Quality
+	}
+
+	go func() {
+		defer close(frames)
+
+		ticker := time.NewTicker(cfg.interval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+				ts := time.Now()
+				buf, err := page.Screenshot(false, req)
+				if err != nil {
+					log.Printf("[RodStream] Error capturing screenshot: %v", err)
+					return // Exit stream on error (browser probably closed)
+				}
+
+				select {
+				case frames <- domain.VideoFrame{Data: buf, Format: cfg.Format, Timestamp: ts}:
+				default:
+					log.Printf("[RodStream] Encoder is behind, dropping frame")
+				}
+			}
+		}
+	}()
+
+	return frames
+}
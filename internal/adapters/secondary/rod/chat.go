@@ -0,0 +1,79 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/ysmood/gson"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// chatFlow is implemented by flows whose platform shows the meeting chat in the DOM.
+type chatFlow interface {
+	// OpenChat performs one step towards opening the chat pane and reports
+	// whether it is now visible.
+	OpenChat(page *rod.Page, locale string) (bool, error)
+	// ChatWatcherJS installs a watcher that calls emit({sender, text, links, time})
+	// once per message, in posting order. Time is epoch milliseconds.
+	ChatWatcherJS() string
+}
+
+const chatOpenAttempts = 10
+
+// startChat opens the chat pane and forwards each message to the observer.
+func (r *RodAdapter) startChat(ctx context.Context, sessionID, locale string, page *rod.Page, flow chatFlow) {
+	opened, err := retryStep(ctx, chatOpenAttempts, func() (bool, error) {
+		return flow.OpenChat(page, locale)
+	})
+	if err != nil {
+		log.Printf("[RodChat] Session %s: %v", sessionID, err)
+		return
+	}
+	if !opened {
+		log.Printf("[RodChat] Could not open the chat pane for session %s", sessionID)
+		return
+	}
+
+	const binding = "__meetingMinutesChat"
+	_, err = page.Expose(binding, func(msg gson.JSON) (interface{}, error) {
+		observer := r.getObserver()
+		if observer == nil {
+			return nil, nil
+		}
+		text := strings.TrimSpace(msg.Get("text").Str())
+		var links []string
+		for _, link := range msg.Get("links").Arr() {
+			if href := strings.TrimSpace(link.Str()); href != "" {
+				links = append(links, href)
+			}
+		}
+		if text == "" && len(links) == 0 {
+			return nil, nil
+		}
+		observer.ChatMessageReceived(sessionID, domain.ChatMessage{
+			Sender: strings.TrimSpace(msg.Get("sender").Str()),
+			Text:   text,
+			Links:  links,
+			Time:   time.UnixMilli(int64(msg.Get("time").Num())),
+		})
+		return nil, nil
+	})
+	if err != nil {
+		log.Printf("[RodChat] Failed to expose chat binding for session %s: %v", sessionID, err)
+		return
+	}
+
+	err = rod.Try(func() {
+		page.MustEval(fmt.Sprintf(`() => (%s)(window.%s)`, flow.ChatWatcherJS(), binding))
+	})
+	if err != nil {
+		log.Printf("[RodChat] Failed to install chat watcher for session %s: %v", sessionID, err)
+		return
+	}
+	fmt.Printf("[RodChat] Capturing meeting chat for session %s\n", sessionID)
+}
@@ -0,0 +1,427 @@
+package rod
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+const (
+	maxDiagScreenshots = 40   // Oldest step screenshots are dropped first
+	maxDiagConsole     = 2000 // Console lines
+	maxDiagRequests    = 3000 // HAR entries
+	diagCaptureTimeout = 10 * time.Second
+)
+
+// diagnostics collects what the join flow saw so a failed join can be
+// investigated afterwards. Collection stops once the bot is in the meeting.
+type diagnostics struct {
+	mu       sync.Mutex
+	started  time.Time
+	shots    []diagShot
+	console  []string
+	requests map[proto.NetworkRequestID]*harEntry // In-flight, by CDP request ID
+	entries  []*harEntry                          // Every request, in the order sent
+	page     *rod.Page                            // Set by watch
+	stop     context.CancelFunc
+	stopped  bool
+}
+
+type diagShot struct {
+	at   time.Time
+	step string
+	data []byte
+}
+
+func newDiagnostics() *diagnostics {
+	return &diagnostics{
+		started:  time.Now(),
+		requests: make(map[proto.NetworkRequestID]*harEntry),
+		stop:     func() {},
+	}
+}
+
+// watch records console output, uncaught exceptions and network traffic
+// until close is called.
+func (d *diagnostics) watch(page *rod.Page) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.page = page
+	d.stop = cancel
+	// EachEvent enables the domains before returning; only the wait runs in
+	// the background
+	wait := page.Context(ctx).EachEvent(
+		func(e *proto.RuntimeConsoleAPICalled) {
+			args := make([]string, 0, len(e.Args))
+			for _, arg := range e.Args {
+				args = append(args, remoteObjectString(arg))
+			}
+			d.log(time.UnixMilli(int64(e.Timestamp)), string(e.Type), strings.Join(args, " "))
+		},
+		func(e *proto.RuntimeExceptionThrown) {
+			text := e.ExceptionDetails.Text
+			if e.ExceptionDetails.Exception != nil {
+				text += " " + remoteObjectString(e.ExceptionDetails.Exception)
+			}
+			d.log(time.UnixMilli(int64(e.Timestamp)), "exception", text)
+		},
+		func(e *proto.NetworkRequestWillBeSent) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			// A redirect reuses the request ID: the previous hop ends here
+			if prev, ok := d.requests[e.RequestID]; ok && e.RedirectResponse != nil {
+				prev.respond(e.RedirectResponse, e.Timestamp)
+				prev.finish(e.Timestamp, 0, "")
+			}
+			if len(d.entries) >= maxDiagRequests {
+				delete(d.requests, e.RequestID)
+				return
+			}
+			entry := newHAREntry(e)
+			d.requests[e.RequestID] = entry
+			d.entries = append(d.entries, entry)
+		},
+		func(e *proto.NetworkResponseReceived) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if entry, ok := d.requests[e.RequestID]; ok {
+				entry.respond(e.Response, e.Timestamp)
+			}
+		},
+		func(e *proto.NetworkLoadingFinished) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if entry, ok := d.requests[e.RequestID]; ok {
+				entry.finish(e.Timestamp, e.EncodedDataLength, "")
+				delete(d.requests, e.RequestID)
+			}
+		},
+		func(e *proto.NetworkLoadingFailed) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if entry, ok := d.requests[e.RequestID]; ok {
+				entry.finish(e.Timestamp, 0, e.ErrorText)
+				delete(d.requests, e.RequestID)
+			}
+		},
+	)
+	go wait()
+}
+
+// close stops collecting. The data is kept until the diagnostics are dropped.
+// EachEvent's own restore runs on the cancelled context and fails, so the
+// Network domain is disabled here; the meeting itself has no use for it.
+func (d *diagnostics) close() {
+	d.mu.Lock()
+	stopped := d.stopped
+	d.stopped = true
+	d.mu.Unlock()
+	if stopped {
+		return
+	}
+
+	d.stop()
+	if d.page != nil {
+		_ = proto.NetworkDisable{}.Call(d.page.Timeout(diagCaptureTimeout))
+	}
+}
+
+func (d *diagnostics) log(at time.Time, level, text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.console) < maxDiagConsole {
+		d.console = append(d.console, fmt.Sprintf("%s [%s] %s", at.UTC().Format(time.RFC3339Nano), level, text))
+	}
+}
+
+// screenshot records the page as it looks at a named join step. A nil
+// diagnostics is valid so flows need not check.
+func (d *diagnostics) screenshot(page *rod.Page, step string) {
+	if d == nil {
+		return
+	}
+	data, err := page.Timeout(diagCaptureTimeout).Screenshot(false, &proto.PageCaptureScreenshot{
+		Format:  proto.PageCaptureScreenshotFormatJpeg,
+		Quality: intPtr(60),
+	})
+	if err != nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.shots) == maxDiagScreenshots {
+		d.shots = d.shots[1:]
+	}
+	d.shots = append(d.shots, diagShot{at: time.Now(), step: step, data: data})
+}
+
+// write captures the final screen and DOM and zips everything collected
+// into path.
+func (d *diagnostics) write(path string, page *rod.Page, session *domain.MeetingSession, flow string, joinErr error) error {
+	d.screenshot(page, "failure")
+	html, err := page.Timeout(diagCaptureTimeout).HTML()
+	if err != nil {
+		html = fmt.Sprintf("<!-- DOM unavailable: %v -->", err)
+	}
+	d.close()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+
+	summary := fmt.Sprintf("session: %s\nflow: %s\nmeeting: %s\nstarted: %s\nfailed: %s\nerror: %v\n",
+		session.ID, flow, session.MeetingURL,
+		d.started.UTC().Format(time.RFC3339), time.Now().UTC().Format(time.RFC3339), joinErr)
+	type diagFile struct {
+		name string
+		data []byte
+	}
+	har, err := d.harLocked()
+	if err != nil {
+		return err
+	}
+	files := []diagFile{
+		{"summary.txt", []byte(summary)},
+		{"dom.html", []byte(html)},
+		{"console.log", []byte(strings.Join(d.console, "\n"))},
+		{"network.har", har},
+	}
+	for i, shot := range d.shots {
+		name := fmt.Sprintf("screenshots/%02d-%s-%s.jpg", i+1, shot.at.UTC().Format("150405.000"), shot.step)
+		files = append(files, diagFile{name, shot.data})
+	}
+
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (d *diagnostics) harLocked() ([]byte, error) {
+	entries := d.entries
+	if entries == nil {
+		entries = []*harEntry{}
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"log": map[string]interface{}{
+			"version": "1.2",
+			"creator": map[string]string{"name": "go-meeting-recorder", "version": "1"},
+			"pages":   []interface{}{},
+			"entries": entries,
+		},
+	}, "", "  ")
+}
+
+func remoteObjectString(obj *proto.RuntimeRemoteObject) string {
+	if obj.Description != "" {
+		return obj.Description
+	}
+	if obj.UnserializableValue != "" {
+		return string(obj.UnserializableValue)
+	}
+	if s, ok := obj.Value.Val().(string); ok {
+		return s
+	}
+	return obj.Value.JSON("", "")
+}
+
+func intPtr(v int) *int { return &v }
+
+// harEntry is the subset of a HAR 1.2 entry that CDP network events can fill in.
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"` // Milliseconds
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"` // Load failure, if any
+
+	sent      proto.MonotonicTime
+	responded proto.MonotonicTime
+}
+
+type harRequest struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []harHeader `json:"headers"`
+	QueryString []harHeader `json:"queryString"`
+	Cookies     []harHeader `json:"cookies"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []harHeader `json:"headers"`
+	Cookies     []harHeader `json:"cookies"`
+	Content     harContent  `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func newHAREntry(e *proto.NetworkRequestWillBeSent) *harEntry {
+	return &harEntry{
+		StartedDateTime: e.WallTime.Time().UTC().Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      e.Request.Method,
+			URL:         redactURL(e.Request.URL),
+			HTTPVersion: "HTTP/1.1",
+			Headers:     harHeaders(e.Request.Headers),
+			QueryString: []harHeader{},
+			Cookies:     []harHeader{},
+			HeadersSize: -1,
+			BodySize:    len(e.Request.PostData),
+		},
+		Response: harResponse{
+			Headers:     []harHeader{},
+			Cookies:     []harHeader{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		sent: e.Timestamp,
+	}
+}
+
+func (h *harEntry) respond(r *proto.NetworkResponse, at proto.MonotonicTime) {
+	h.responded = at
+	h.Response.Status = r.Status
+	h.Response.StatusText = r.StatusText
+	h.Response.HTTPVersion = r.Protocol
+	h.Response.Headers = harHeaders(r.Headers)
+	h.Response.Content.MimeType = r.MIMEType
+	for name, value := range r.Headers {
+		if strings.EqualFold(name, "location") {
+			h.Response.RedirectURL = redactURL(value.Str())
+		}
+	}
+}
+
+func (h *harEntry) finish(at proto.MonotonicTime, size float64, failure string) {
+	ms := func(d proto.MonotonicTime) float64 { return float64(d) * 1000 }
+	h.Time = ms(at - h.sent)
+	h.Response.BodySize = int(size)
+	h.Response.Content.Size = int(size)
+	h.Comment = failure
+	if h.responded > 0 {
+		h.Timings.Wait = ms(h.responded - h.sent)
+		h.Timings.Receive = ms(at - h.responded)
+	} else {
+		h.Timings.Wait = h.Time
+	}
+}
+
+// The bundle is handed around when a join fails, so it must not carry the
+// bot's login. Credential headers keep their name with the value replaced,
+// and token-like query parameters are dropped from every URL.
+const redacted = "[redacted]"
+
+var (
+	secretHeaders = map[string]bool{
+		"cookie":              true,
+		"set-cookie":          true,
+		"authorization":       true,
+		"proxy-authorization": true,
+	}
+	// Matched against query parameter names
+	secretParamPattern = regexp.MustCompile(`(?i)token|auth|key|secret|passw|pwd|passcode|sig|session|code|ticket|credential|saml|jwt`)
+	// Long opaque values are treated as tokens whatever the parameter is called
+	tokenValuePattern = regexp.MustCompile(`^(eyJ[\w-]*\.[\w-]*\.[\w-]*|[\w\-.~+/=%]{32,})$`)
+)
+
+func harHeaders(headers proto.NetworkHeaders) []harHeader {
+	out := make([]harHeader, 0, len(headers))
+	for name, value := range headers {
+		v := value.Str()
+		switch lower := strings.ToLower(name); {
+		case secretHeaders[lower]:
+			v = redacted
+		case lower == "referer" || lower == "location":
+			v = redactURL(v)
+		}
+		out = append(out, harHeader{Name: name, Value: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// redactURL drops query parameters that look like credentials, and the
+// fragment, where OAuth implicit flows put their tokens.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	u.Fragment, u.RawFragment = "", ""
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		u.RawQuery = ""
+		return u.String()
+	}
+	for name, values := range query {
+		if secretParamPattern.MatchString(name) {
+			delete(query, name)
+			continue
+		}
+		for _, v := range values {
+			if tokenValuePattern.MatchString(v) {
+				delete(query, name)
+				break
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
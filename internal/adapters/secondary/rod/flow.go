@@ -0,0 +1,32 @@
+package rod
+
+import (
+	"context"
+
+	"github.com/go-rod/rod"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// joinFlow is the platform-specific part of driving a meeting page.
+// Browser launch, capture and teardown are shared by RodAdapter. locale is
+// the language the meeting client shows, already defaulted; "en" when unset.
+type joinFlow interface {
+	Name() string
+	// MeetingURL rewrites the user-facing link into the one the bot should
+	// open, as a guest or signed in with a bot profile
+	MeetingURL(raw string, signedIn bool) string
+	// Referer is sent with every request from the page
+	Referer() string
+	// Join drives the pre-join screen until the bot has asked to enter,
+	// taking a diag screenshot at each step
+	Join(ctx context.Context, page *rod.Page, session *domain.MeetingSession, locale string, diag *diagnostics) error
+	// Admission classifies the page once Join has returned
+	Admission(page *rod.Page, locale string) (admission, error)
+	// HasEnded reports why the page shows the bot is no longer in the meeting,
+	// or "" while it is still in
+	HasEnded(page *rod.Page, locale string) (domain.StopReason, error)
+	// SignedOut reports whether the page asks a profile's bot to sign in,
+	// meaning its login has expired
+	SignedOut(page *rod.Page, locale string) (bool, error)
+}
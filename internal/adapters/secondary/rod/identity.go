@@ -0,0 +1,209 @@
+package rod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// A bot profile keeps its login under <profile dir>/<profile id>:
+//
+//	chrome/               persistent Chrome user data, reused by every join
+//	storage-state.json    the last uploaded login
+//	storage-state.fresh   the upload's version, until it has been applied once
+//
+// A fresh upload overwrites what the browser has. Later joins only restore
+// cookies and storage items the browser lost (session cookies do not survive
+// a restart), so tokens the site refreshed are kept.
+type identityPaths struct {
+	userData string
+	state    string
+	fresh    string
+}
+
+func profilePaths(dir, profileID string) (identityPaths, error) {
+	if profileID == "" || filepath.Base(profileID) != profileID || profileID == "." || profileID == ".." {
+		return identityPaths{}, fmt.Errorf("invalid profile id %q", profileID)
+	}
+	root := filepath.Join(dir, profileID)
+	return identityPaths{
+		userData: filepath.Join(root, "chrome"),
+		state:    filepath.Join(root, "storage-state.json"),
+		fresh:    filepath.Join(root, "storage-state.fresh"),
+	}, nil
+}
+
+// stateMu orders uploads against joins reading or clearing the marker, so a
+// join never pairs one upload's state with another's version.
+var stateMu sync.Mutex
+
+type identityStore struct {
+	dir string
+}
+
+// NewIdentityStore keeps bot profile logins under dir, where the automators
+// created with the same Config.ProfileDir find them.
+func NewIdentityStore(dir string) ports.IdentityStore {
+	return &identityStore{dir: dir}
+}
+
+func (s *identityStore) SaveStorageState(ctx context.Context, profileId string, state domain.StorageState) error {
+	paths, err := profilePaths(s.dir, profileId)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(paths.state), 0700); err != nil {
+		return err
+	}
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	// Write then rename, so a join never reads half a file
+	tmp := paths.state + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, paths.state); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	version := strconv.FormatInt(time.Now().UnixNano(), 10)
+	return os.WriteFile(paths.fresh, []byte(version), 0600)
+}
+
+func (s *identityStore) DeleteIdentity(ctx context.Context, profileId string) error {
+	paths, err := profilePaths(s.dir, profileId)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Dir(paths.state))
+}
+
+// loadStorageState reads the profile's uploaded login. ok is false when none
+// was uploaded; fresh is true, with the upload's version, until markApplied
+// is called for that version.
+func loadStorageState(paths identityPaths) (state domain.StorageState, fresh bool, version string, ok bool, err error) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	data, err := os.ReadFile(paths.state)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, false, "", false, nil
+	}
+	if err != nil {
+		return state, false, "", false, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, false, "", false, fmt.Errorf("stored storage state is unreadable: %w", err)
+	}
+	marker, err := os.ReadFile(paths.fresh)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, false, "", true, nil
+	}
+	if err != nil {
+		return state, false, "", false, err
+	}
+	return state, true, string(marker), true, nil
+}
+
+// markApplied records that the upload with the given version has reached the
+// profile's browser data. A newer upload stays fresh for the next join.
+func markApplied(paths identityPaths, version string) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	current, err := os.ReadFile(paths.fresh)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err == nil && string(current) != version {
+		fmt.Printf("[Rod] Storage state in %s was replaced during the join; keeping it fresh\n", filepath.Dir(paths.fresh))
+		return
+	}
+	if err == nil {
+		err = os.Remove(paths.fresh)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("[Rod] Failed to clear %s: %v\n", paths.fresh, err)
+	}
+}
+
+// applyStorageState loads the login into the browser before the meeting page
+// opens: cookies directly, local storage as each origin's first document loads.
+func applyStorageState(browser *rod.Browser, page *rod.Page, state domain.StorageState, overwrite bool) error {
+	present := map[string]bool{}
+	if !overwrite {
+		existing, err := browser.GetCookies()
+		if err != nil {
+			return err
+		}
+		for _, c := range existing {
+			present[c.Domain+"\x00"+c.Path+"\x00"+c.Name] = true
+		}
+	}
+
+	var cookies []*proto.NetworkCookieParam
+	for _, c := range state.Cookies {
+		path := c.Path
+		if path == "" {
+			path = "/"
+		}
+		if present[c.Domain+"\x00"+path+"\x00"+c.Name] {
+			continue
+		}
+		cookie := &proto.NetworkCookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     path,
+			Secure:   c.Secure,
+			HTTPOnly: c.HTTPOnly,
+			SameSite: proto.NetworkCookieSameSite(c.SameSite),
+		}
+		if c.Expires > 0 {
+			cookie.Expires = proto.TimeSinceEpoch(c.Expires)
+		}
+		cookies = append(cookies, cookie)
+	}
+	if len(cookies) > 0 {
+		if err := browser.SetCookies(cookies); err != nil {
+			return err
+		}
+	}
+
+	if len(state.Origins) == 0 {
+		return nil
+	}
+	origins := make(map[string][]domain.StorageItem, len(state.Origins))
+	for _, o := range state.Origins {
+		origins[o.Origin] = o.LocalStorage
+	}
+	originsJSON, err := json.Marshal(origins)
+	if err != nil {
+		return err
+	}
+	_, err = page.EvalOnNewDocument(fmt.Sprintf(`(() => {
+		const items = %s[location.origin];
+		if (!items || sessionStorage.getItem('__mmStorageApplied')) return;
+		for (const { name, value } of items) {
+			if (%t || localStorage.getItem(name) === null) localStorage.setItem(name, value);
+		}
+		sessionStorage.setItem('__mmStorageApplied', '1');
+	})()`, originsJSON, overwrite))
+	return err
+}
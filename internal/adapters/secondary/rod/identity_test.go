@@ -0,0 +1,71 @@
+package rod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+func testStorageState(value string) domain.StorageState {
+	return domain.StorageState{Cookies: []domain.StorageCookie{{Name: "session", Value: value, Domain: ".example.com", Path: "/"}}}
+}
+
+func TestStorageStateStaysFreshUntilApplied(t *testing.T) {
+	dir := t.TempDir()
+	store := NewIdentityStore(dir)
+	paths, err := profilePaths(dir, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, ok, err := loadStorageState(paths); ok || err != nil {
+		t.Fatalf("load before any upload: ok=%v err=%v", ok, err)
+	}
+
+	if err := store.SaveStorageState(context.Background(), "p1", testStorageState("one")); err != nil {
+		t.Fatal(err)
+	}
+	state, fresh, version, ok, err := loadStorageState(paths)
+	if err != nil || !ok || !fresh {
+		t.Fatalf("load after upload: fresh=%v ok=%v err=%v", fresh, ok, err)
+	}
+	if got := state.Cookies[0].Value; got != "one" {
+		t.Errorf("loaded cookie %q, want %q", got, "one")
+	}
+
+	markApplied(paths, version)
+	if _, fresh, _, ok, _ := loadStorageState(paths); !ok || fresh {
+		t.Errorf("still fresh after being applied (ok=%v)", ok)
+	}
+}
+
+func TestStorageStateUploadedDuringJoinStaysFresh(t *testing.T) {
+	dir := t.TempDir()
+	store := NewIdentityStore(dir)
+	paths, _ := profilePaths(dir, "p1")
+
+	if err := store.SaveStorageState(context.Background(), "p1", testStorageState("one")); err != nil {
+		t.Fatal(err)
+	}
+	_, _, joined, _, _ := loadStorageState(paths)
+
+	// A new login arrives while the session that loaded the old one is joining
+	time.Sleep(time.Millisecond)
+	if err := store.SaveStorageState(context.Background(), "p1", testStorageState("two")); err != nil {
+		t.Fatal(err)
+	}
+	markApplied(paths, joined)
+
+	state, fresh, _, _, err := loadStorageState(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fresh {
+		t.Error("the newer upload was marked applied by a join that used the older one")
+	}
+	if got := state.Cookies[0].Value; got != "two" {
+		t.Errorf("loaded cookie %q, want %q", got, "two")
+	}
+}
@@ -0,0 +1,325 @@
+package rod
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The flow scripts are written against the English client. A locale file maps
+// their English phrases, and those of the call controls a flow drives in code,
+// per flow, to what the localized client shows; the translations are matched
+// in addition to the English text. Files in
+// <flow dir>/locales replace the built-in file for the same locale.
+//
+//go:embed flows/locales/*.yaml
+var builtinLocales embed.FS
+
+const defaultLocale = "en"
+
+// localeText is one locale file: flow name -> English phrase -> translations.
+type localeText map[string]map[string]textList
+
+func parseLocaleText(data []byte) (localeText, error) {
+	var text localeText
+	if err := yaml.Unmarshal(data, &text); err != nil {
+		return nil, err
+	}
+	for flow, phrases := range text {
+		for phrase, translations := range phrases {
+			if phrase == "" || len(translations) == 0 {
+				return nil, fmt.Errorf("%s: empty phrase or translation", flow)
+			}
+		}
+	}
+	return text, nil
+}
+
+// localeCatalog serves locale files, reloading overrides when they change.
+type localeCatalog struct {
+	dir     string
+	builtin map[string]localeText
+
+	mu        sync.Mutex
+	overrides map[string]*localeOverride // By locale
+	missing   map[string]bool            // Locales already reported as unknown
+}
+
+type localeOverride struct {
+	modTime time.Time
+	text    localeText // Last good version, nil if none
+}
+
+func newLocaleCatalog(dir string) *localeCatalog {
+	c := &localeCatalog{
+		dir:       dir,
+		builtin:   make(map[string]localeText),
+		overrides: make(map[string]*localeOverride),
+		missing:   make(map[string]bool),
+	}
+	files, _ := fs.Glob(builtinLocales, "flows/locales/*.yaml")
+	for _, file := range files {
+		data, err := builtinLocales.ReadFile(file)
+		if err != nil {
+			panic(fmt.Sprintf("missing built-in locale %s: %v", file, err))
+		}
+		text, err := parseLocaleText(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in locale %s: %v", file, err))
+		}
+		c.builtin[strings.TrimSuffix(path.Base(file), ".yaml")] = text
+	}
+	return c
+}
+
+// phrases returns the translations a flow should match for locale. A
+// regional locale such as de-AT falls back to its language; English and
+// unknown locales have none.
+func (c *localeCatalog) phrases(locale, flow string) map[string]textList {
+	locale = normalizeLocale(locale)
+	if locale == defaultLocale || strings.HasPrefix(locale, defaultLocale+"-") {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, candidate := range localeFallbacks(locale) {
+		if text := c.lookupLocked(candidate); text != nil {
+			return text[flow]
+		}
+	}
+	if !c.missing[locale] {
+		c.missing[locale] = true
+		log.Printf("[RodScript] No UI text for locale %q; matching English only", locale)
+	}
+	return nil
+}
+
+func (c *localeCatalog) lookupLocked(locale string) localeText {
+	file, info := c.find(locale)
+	if file == "" {
+		return c.builtin[locale]
+	}
+	override := c.overrides[locale]
+	if override == nil {
+		override = &localeOverride{}
+		c.overrides[locale] = override
+	}
+	if !info.ModTime().Equal(override.modTime) {
+		override.modTime = info.ModTime()
+		data, err := os.ReadFile(file)
+		var text localeText
+		if err == nil {
+			text, err = parseLocaleText(data)
+		}
+		if err != nil {
+			log.Printf("[RodScript] Ignoring %s: %v", file, err)
+		} else {
+			log.Printf("[RodScript] Loaded locale %s from %s", locale, file)
+			override.text = text
+		}
+	}
+	if override.text != nil {
+		return override.text
+	}
+	return c.builtin[locale]
+}
+
+// find returns the override file for locale, if there is one.
+func (c *localeCatalog) find(locale string) (string, os.FileInfo) {
+	if c.dir == "" {
+		return "", nil
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		file := filepath.Join(c.dir, "locales", locale+ext)
+		if info, err := os.Stat(file); err == nil {
+			return file, info
+		}
+	}
+	return "", nil
+}
+
+// normalizeLocale lowercases a language tag and uses "-" as the separator.
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if locale == "" {
+		return defaultLocale
+	}
+	return locale
+}
+
+// localeFallbacks lists a tag and its shorter prefixes: zh-hant-tw, zh-hant, zh.
+func localeFallbacks(locale string) []string {
+	var out []string
+	for {
+		out = append(out, locale)
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return out
+		}
+		locale = locale[:i]
+	}
+}
+
+// browserLanguages is what the browser advertises for locale, most preferred
+// first, with English kept as the last resort.
+func browserLanguages(locale string) []string {
+	locale = normalizeLocale(locale)
+	if locale == defaultLocale {
+		return []string{"en-US", "en"}
+	}
+	var tags []string
+	for _, tag := range localeFallbacks(locale) {
+		tags = append(tags, canonicalTag(tag))
+	}
+	for _, tag := range []string{"en-US", "en"} {
+		if !containsFold(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// canonicalTag restores the usual casing of a normalized tag: fr-ca -> fr-CA,
+// zh-hant -> zh-Hant.
+func canonicalTag(tag string) string {
+	parts := strings.Split(tag, "-")
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// acceptLanguage formats languages as an Accept-Language header value.
+func acceptLanguage(languages []string) string {
+	parts := make([]string, len(languages))
+	for i, lang := range languages {
+		if i == 0 {
+			parts[i] = lang
+			continue
+		}
+		q := 1 - float64(i)/10
+		if q < 0.1 {
+			q = 0.1
+		}
+		parts[i] = fmt.Sprintf("%s;q=%.1f", lang, q)
+	}
+	return strings.Join(parts, ",")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// localize returns a copy of the script that also matches the translated
+// phrases. Phrases are looked up in step and rule text, and as quoted
+// attribute values in selectors.
+func (s *joinScript) localize(phrases map[string]textList) *joinScript {
+	if len(phrases) == 0 {
+		return s
+	}
+	out := *s
+	out.Join.Steps = make([]scriptStep, len(s.Join.Steps))
+	for i, step := range s.Join.Steps {
+		step.Text = localizeText(step.Text, phrases)
+		step.Selector = localizeSelector(step.Selector, phrases)
+		out.Join.Steps[i] = step
+	}
+	out.Admission = make([]admissionRule, len(s.Admission))
+	for i, rule := range s.Admission {
+		rule.pageMatch = rule.pageMatch.localize(phrases)
+		out.Admission[i] = rule
+	}
+	out.Ended = make([]endedRule, len(s.Ended))
+	for i, rule := range s.Ended {
+		rule.pageMatch = rule.pageMatch.localize(phrases)
+		out.Ended[i] = rule
+	}
+	out.SignedOut = s.SignedOut.localize(phrases)
+	return &out
+}
+
+func (m pageMatch) localize(phrases map[string]textList) pageMatch {
+	return pageMatch{
+		Selector: localizeSelector(m.Selector, phrases),
+		Text:     localizeText(m.Text, phrases),
+		URL:      m.URL,
+	}
+}
+
+func localizeText(text textList, phrases map[string]textList) textList {
+	if len(text) == 0 {
+		return text
+	}
+	out := append(textList{}, text...)
+	for _, phrase := range text {
+		out = append(out, phrases[phrase]...)
+	}
+	return out
+}
+
+var (
+	quotedValue = regexp.MustCompile(`"([^"]*)"`)
+	cssEscaper  = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// localizeSelector adds a copy of each selector in the list for every
+// translation of a quoted attribute value, so button[aria-label="Leave"]
+// also finds button[aria-label="Verlassen"].
+func localizeSelector(selector string, phrases map[string]textList) string {
+	if selector == "" {
+		return selector
+	}
+	var out []string
+	for _, part := range splitSelectorList(selector) {
+		out = append(out, part)
+		for _, m := range quotedValue.FindAllStringSubmatchIndex(part, -1) {
+			phrase := part[m[2]:m[3]]
+			for _, translation := range phrases[phrase] {
+				out = append(out, part[:m[0]]+`"`+cssEscaper.Replace(translation)+`"`+part[m[1]:])
+			}
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+// splitSelectorList splits a CSS selector list on the commas between selectors.
+func splitSelectorList(selector string) []string {
+	var parts []string
+	depth, quoted, start := 0, false, 0
+	for i, c := range selector {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(selector[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(selector[start:]))
+}
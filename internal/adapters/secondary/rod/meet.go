@@ -0,0 +1,43 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-rod/rod"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// meetFlow takes its join steps and screen detection from the meet script.
+type meetFlow struct {
+	script *scriptSource
+}
+
+func NewMeetAutomator(cfg Config) ports.BrowserAutomator {
+	return newRodAdapter(meetFlow{script: newScriptSource("meet", cfg.FlowDir)}, cfg)
+}
+
+func (meetFlow) Name() string { return "Meet" }
+
+func (meetFlow) Referer() string { return "https://meet.google.com/" }
+
+func (meetFlow) MeetingURL(raw string, signedIn bool) string { return raw }
+
+func (f meetFlow) Join(ctx context.Context, page *rod.Page, session *domain.MeetingSession, locale string, diag *diagnostics) error {
+	fmt.Println("[Rod] Handling Meet join flow...")
+	return f.script.current(locale).runJoin(ctx, page, session, session.ProfileID != "", diag)
+}
+
+func (f meetFlow) Admission(page *rod.Page, locale string) (admission, error) {
+	return f.script.current(locale).admission(page)
+}
+
+func (f meetFlow) HasEnded(page *rod.Page, locale string) (domain.StopReason, error) {
+	return f.script.current(locale).ended(page)
+}
+
+func (f meetFlow) SignedOut(page *rod.Page, locale string) (bool, error) {
+	return f.script.current(locale).signedOut(page)
+}
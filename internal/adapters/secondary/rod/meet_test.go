@@ -0,0 +1,220 @@
+package rod
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// The Meet flow is driven against saved pre-join and in-call pages from
+// testdata/meet, served locally. The tests need a Chrome or Chromium install
+// and are skipped without one.
+
+func newFixtureBrowser(t *testing.T) (*rod.Browser, string) {
+	t.Helper()
+	bin, ok := launcher.LookPath()
+	if !ok {
+		t.Skip("no Chrome or Chromium found")
+	}
+	l := launcher.New().Bin(bin).Headless(true).Set("no-sandbox")
+	u, err := l.Launch()
+	if err != nil {
+		t.Fatalf("launch browser: %v", err)
+	}
+	browser := rod.New().ControlURL(u)
+	if err := browser.Connect(); err != nil {
+		l.Kill()
+		t.Fatalf("connect to browser: %v", err)
+	}
+	t.Cleanup(func() {
+		browser.Close()
+		l.Cleanup()
+	})
+
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata/meet")))
+	t.Cleanup(server.Close)
+	return browser, server.URL
+}
+
+func openFixture(t *testing.T, browser *rod.Browser, url string) *rod.Page {
+	t.Helper()
+	page, err := browser.Page(proto.TargetCreateTarget{URL: url})
+	if err != nil {
+		t.Fatalf("open %s: %v", url, err)
+	}
+	if err := page.WaitLoad(); err != nil {
+		t.Fatalf("load %s: %v", url, err)
+	}
+	t.Cleanup(func() { page.Close() })
+	return page
+}
+
+func newTestMeetFlow() meetFlow {
+	return meetFlow{script: newScriptSource("meet", "")}
+}
+
+func TestMeetJoinAsGuest(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	page := openFixture(t, browser, base+"/prejoin.html")
+	flow := newTestMeetFlow()
+	session := &domain.MeetingSession{ID: "s1", ParticipantName: "Minutes Bot"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := flow.Join(ctx, page, session, "en", nil); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+
+	if got := page.MustEval(`() => window.requestedName`).String(); got != "Minutes Bot" {
+		t.Errorf("asked to join as %q, want %q", got, "Minutes Bot")
+	}
+	state, err := flow.Admission(page, "en")
+	if err != nil {
+		t.Fatalf("Admission: %v", err)
+	}
+	if state != admissionLobby {
+		t.Errorf("admission = %s, want %s", state, admissionLobby)
+	}
+}
+
+func TestMeetJoinMutesDevices(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	page := openFixture(t, browser, base+"/prejoin.html")
+
+	// Stop right after devices-off, before the page is replaced by the lobby
+	script := *newTestMeetFlow().script.current("en")
+	script.Join.Steps = script.Join.Steps[:2]
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := script.runJoin(ctx, page, &domain.MeetingSession{}, false, nil); err != nil {
+		t.Fatalf("runJoin: %v", err)
+	}
+
+	if n := page.MustEval(`() => document.querySelectorAll('[data-is-muted="false"]').length`).Int(); n != 0 {
+		t.Errorf("%d devices still on", n)
+	}
+	if page.MustEval(`() => !!document.getElementById('device-prompt')`).Bool() {
+		t.Error("device prompt was not dismissed")
+	}
+}
+
+func TestMeetJoinSignedIn(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	page := openFixture(t, browser, base+"/prejoin_signed_in.html")
+	flow := newTestMeetFlow()
+	session := &domain.MeetingSession{ID: "s1", ParticipantName: "Minutes Bot", ProfileID: "p1"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := flow.Join(ctx, page, session, "en", nil); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+
+	state, err := flow.Admission(page, "en")
+	if err != nil {
+		t.Fatalf("Admission: %v", err)
+	}
+	if state != admissionInMeeting {
+		t.Errorf("admission = %s, want %s", state, admissionInMeeting)
+	}
+}
+
+func TestMeetJoinLocalized(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	page := openFixture(t, browser, base+"/prejoin_de.html")
+	flow := newTestMeetFlow()
+	session := &domain.MeetingSession{ID: "s1", ParticipantName: "Protokoll"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := flow.Join(ctx, page, session, "de", nil); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+
+	if got := page.MustEval(`() => window.requestedName`).String(); got != "Protokoll" {
+		t.Errorf("asked to join as %q, want %q", got, "Protokoll")
+	}
+	state, err := flow.Admission(page, "de")
+	if err != nil {
+		t.Fatalf("Admission: %v", err)
+	}
+	if state != admissionLobby {
+		t.Errorf("admission = %s, want %s", state, admissionLobby)
+	}
+}
+
+func TestMeetAdmission(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	flow := newTestMeetFlow()
+
+	tests := []struct {
+		fixture string
+		want    admission
+	}{
+		{"in_call.html", admissionInMeeting},
+		{"denied.html", admissionDenied},
+		{"prejoin.html", admissionPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			page := openFixture(t, browser, base+"/"+tt.fixture)
+			got, err := flow.Admission(page, "en")
+			if err != nil {
+				t.Fatalf("Admission: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("admission = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeetHasEnded(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	flow := newTestMeetFlow()
+
+	tests := []struct {
+		fixture string
+		want    domain.StopReason
+	}{
+		{"in_call.html", ""},
+		{"removed.html", domain.StopRemoved},
+		{"call_ended.html", domain.StopMeetingEnded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			page := openFixture(t, browser, base+"/"+tt.fixture)
+			got, err := flow.HasEnded(page, "en")
+			if err != nil {
+				t.Fatalf("HasEnded: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasEnded = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeetSignedOut(t *testing.T) {
+	browser, base := newFixtureBrowser(t)
+	flow := newTestMeetFlow()
+
+	for fixture, want := range map[string]bool{"sign_in.html": true, "prejoin_signed_in.html": false} {
+		page := openFixture(t, browser, base+"/"+fixture)
+		got, err := flow.SignedOut(page, "en")
+		if err != nil {
+			t.Fatalf("SignedOut(%s): %v", fixture, err)
+		}
+		if got != want {
+			t.Errorf("SignedOut(%s) = %v, want %v", fixture, got, want)
+		}
+	}
+}
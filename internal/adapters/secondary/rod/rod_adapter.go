@@ -2,10 +2,12 @@ package rod
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"strings"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 
+	"go-meeting-recorder/internal/adapters/secondary/pulse"
 	"go-meeting-recorder/internal/core/domain"
 	"go-meeting-recorder/internal/core/ports"
 )
@@ -20,25 +23,108 @@ import (
 type RodAdapter struct {
 	browsers map[string]*rod.Browser
 	pages    map[string]*rod.Page
+	sinks    map[string]*pulse.Sink // Per-session audio output
 	mu       sync.Mutex
 	stopCh   map[string]chan struct{} // Channel to signal stop to monitoring routine
+	capture  CaptureConfig
+	flow     joinFlow
+	observer ports.MeetingObserver
+	diagDir  string // Failed joins leave a diagnostics bundle here
+	locale   string // Client language for sessions that set none
+	profiles string // Bot profile logins, see identity.go
+
+	sessionProfiles string                    // Parent of guest sessions' temporary profiles
+	profileTemplate string                    // Copied into each temporary profile; empty starts blank
+	tempProfiles    map[string]sessionProfile // By session, while the browser runs
 }
 
-func NewRodAutomator() ports.BrowserAutomator {
-	return &RodAdapter{
+// Config is shared by the rod-driven automators.
+type Config struct {
+	Capture        CaptureConfig
+	DiagnosticsDir string // Failed joins leave a diagnostics bundle here
+	FlowDir        string // Join-flow scripts here replace the built-in ones; empty uses the built-ins
+	Locale         string // Client language for sessions that set none; empty is English
+	ProfileDir     string // Bot profile logins, shared with NewIdentityStore
+	// SessionProfileDir holds each guest session's temporary browser profile;
+	// empty uses the system temp dir. ProfileTemplate, if set, seeds them.
+	SessionProfileDir string
+	ProfileTemplate   string
+}
+
+func newRodAdapter(flow joinFlow, cfg Config) *RodAdapter {
+	sessionProfiles := cfg.SessionProfileDir
+	if sessionProfiles == "" {
+		sessionProfiles = filepath.Join(os.TempDir(), "meeting-recorder-profiles")
+	}
+	r := &RodAdapter{
+		flow:     flow,
+		capture:  cfg.Capture,
+		diagDir:  cfg.DiagnosticsDir,
+		locale:   normalizeLocale(cfg.Locale),
+		profiles: cfg.ProfileDir,
+
+		sessionProfiles: sessionProfiles,
+		profileTemplate: cfg.ProfileTemplate,
+		tempProfiles:    make(map[string]sessionProfile),
+
 		browsers: make(map[string]*rod.Browser),
 		pages:    make(map[string]*rod.Page),
+		sinks:    make(map[string]*pulse.Sink),
 		stopCh:   make(map[string]chan struct{}),
 	}
+	r.sweepSessionProfiles()
+	pulse.RemoveStaleSinks()
+	return r
 }
 
 func (r *RodAdapter) JoinMeeting(ctx context.Context, session *domain.MeetingSession) error {
-	log.Println("Starting Rod automation for Teams...")
+	log.Printf("Starting Rod automation for %s...", r.flow.Name())
+
+	// Ask for the client in the session's language; the flow matches its UI
+	// text in that language too, since tenants can force their own
+	locale := r.localeFor(session)
+	languages := browserLanguages(locale)
+
+	// A bot profile brings its own persistent browser data; a guest gets a
+	// temporary profile that is removed when its browser exits
+	var userDataDir string
+	var identity *identityPaths
+	if session.ProfileID != "" {
+		paths, err := profilePaths(r.profiles, session.ProfileID)
+		if err != nil {
+			return err
+		}
+		identity = &paths
+		userDataDir = paths.userData
+		clearProfileLock(userDataDir)
+	} else {
+		dir, err := r.createSessionProfile(session.ID)
+		if err != nil {
+			return err
+		}
+		userDataDir = dir
+	}
+
+	// Give Chrome its own sink so concurrent sessions don't mix audio
+	env := os.Environ()
+	sink, err := pulse.CreateSink(session.ID)
+	if err != nil {
+		log.Printf("[Rod] Audio capture unavailable for session %s: %v", session.ID, err)
+	} else {
+		env = sink.Env()
+		r.mu.Lock()
+		r.sinks[session.ID] = sink
+		r.mu.Unlock()
+	}
 
+	// ctx bounds the launch itself; a stop during startup kills Chrome
 	l := launcher.New().
+		Context(ctx).
+		Env(env...).
 		Bin("/usr/bin/google-chrome").
-		UserDataDir("/tmp/rod-teams-profile").
+		UserDataDir(userDataDir).
 		Headless(true).
+		Set("lang", languages[0]).
 		Set("no-sandbox").
 		Set("disable-gpu").
 		Set("disable-software-rasterizer").
@@ -57,14 +143,29 @@ func (r *RodAdapter) JoinMeeting(ctx context.Context, session *domain.MeetingSes
 
 	u, err := l.Launch()
 	if err != nil {
+		l.Kill()
+		if identity == nil {
+			os.RemoveAll(userDataDir)
+		}
+		r.StopMeeting(ctx, session.ID)
 		return fmt.Errorf("failed to launch browser: %w", err)
 	}
+	if identity == nil {
+		r.mu.Lock()
+		r.tempProfiles[session.ID] = sessionProfile{dir: userDataDir, launcher: l}
+		r.mu.Unlock()
+	}
 
-	browser := rod.New().ControlURL(u).MustConnect()
+	browser := rod.New().ControlURL(u)
+	if err := browser.Connect(); err != nil {
+		r.StopMeeting(ctx, session.ID)
+		return fmt.Errorf("failed to connect to browser: %w", err)
+	}
 
+	stop := make(chan struct{})
 	r.mu.Lock()
 	r.browsers[session.ID] = browser
-	r.stopCh[session.ID] = make(chan struct{})
+	r.stopCh[session.ID] = stop
 	r.mu.Unlock()
 
 	proto.BrowserGrantPermissions{
@@ -76,24 +177,13 @@ func (r *RodAdapter) JoinMeeting(ctx context.Context, session *domain.MeetingSes
 		},
 	}.Call(browser)
 
-	page := browser.MustPage("")
-
-	page.MustEvalOnNewDocument(`
-		const winUA = 'Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36';
-		Object.defineProperty(navigator, 'userAgent', { get: () => winUA });
-		Object.defineProperty(navigator, 'webdriver', { get: () => false });
-		Object.defineProperty(navigator, 'platform', { get: () => 'Win32' });
-		Object.defineProperty(navigator, 'vendor', { get: () => 'Google Inc.' });
-		Object.defineProperty(navigator, 'languages', { get: () => ['en-US', 'en'] });
-		Object.defineProperty(navigator, 'hardwareConcurrency', { get: () => 8 });
-		Object.defineProperty(navigator, 'deviceMemory', { get: () => 8 });
-		
-		window.chrome = { runtime: {} };
-		delete navigator.__proto__.webdriver;
-	`)
-
-	page.MustSetViewport(1920, 1080, 1, false)
-	page.SetExtraHeaders([]string{"Accept-Language", "en-US,en;q=0.9"})
+	// A stop closes the browser under us, so page setup must fail with an
+	// error rather than panic the pipeline goroutine
+	page, err := r.newPage(browser, languages)
+	if err != nil {
+		r.StopMeeting(context.Background(), session.ID)
+		return fmt.Errorf("failed to open page: %w", err)
+	}
 
 	go page.HandleDialog()
 
@@ -101,187 +191,299 @@ func (r *RodAdapter) JoinMeeting(ctx context.Context, session *domain.MeetingSes
 	r.pages[session.ID] = page
 	r.mu.Unlock()
 
-	go page.EachEvent(func(e *proto.RuntimeConsoleAPICalled) {
-		// Suppress verbose console logs if needed, or keep for debug
-	})()
+	// Console output and network traffic are kept until the bot is in the
+	// meeting, for the diagnostics bundle of a failed join
+	diag := newDiagnostics()
+	diag.watch(page)
 
-	// URL Handling
-	finalURL := session.MeetingURL
-	if strings.Contains(finalURL, "teams.live.com/meet/") {
-		parts := strings.Split(finalURL, "teams.live.com/meet/")
-		if len(parts) > 1 {
-			remaining := parts[1]
-			meetingID := strings.Split(remaining, "?")[0]
-			pVal := ""
-			if strings.Contains(remaining, "p=") {
-				pParts := strings.Split(remaining, "p=")
-				if len(pParts) > 1 {
-					pVal = strings.Split(pParts[1], "&")[0]
-				}
-			}
-			finalURL = fmt.Sprintf("https://teams.live.com/_#/meet/%s?p=%s&anon=true", meetingID, pVal)
-			fmt.Printf("[Rod] Forced Deep Link URL: %s\n", finalURL)
+	freshLogin, loginVersion := false, ""
+	if identity != nil {
+		state, fresh, version, ok, err := loadStorageState(*identity)
+		if err == nil && ok {
+			err = applyStorageState(browser, page, state, fresh)
+		}
+		if err != nil {
+			diag.close()
+			r.StopMeeting(context.Background(), session.ID)
+			return fmt.Errorf("failed to load login for profile %s: %w", session.ProfileID, err)
 		}
+		freshLogin, loginVersion = fresh, version
 	}
 
-	page.MustSetUserAgent(&proto.NetworkSetUserAgentOverride{
-		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
-		Platform:  "Windows",
-	})
-	
-	page.MustSetExtraHeaders("referer", "https://teams.live.com/", "sec-ch-ua-platform", "Windows")
-
+	finalURL := r.flow.MeetingURL(session.MeetingURL, identity != nil)
 	fmt.Printf("[Rod] Navigating to: %s\n", finalURL)
 	_ = page.Navigate(finalURL)
-	time.Sleep(5 * time.Second)
-	
+	if err := sleep(ctx, 5*time.Second); err != nil {
+		diag.close()
+		r.StopMeeting(context.Background(), session.ID)
+		return err
+	}
+	diag.screenshot(page, "navigated")
+	if identity != nil {
+		if err := r.checkSignedOut(page, locale); err != nil {
+			r.saveDiagnostics(session, page, diag, err)
+			r.StopMeeting(context.Background(), session.ID)
+			return err
+		}
+	}
+
 	fmt.Println("[Rod] Initial navigation complete, handling join flow...")
-	fmt.Println("[Rod] Handling Teams join flow...")
-
-	lobbyReached := false
-	startTime := time.Now()
-
-	for time.Since(startTime) < 45*time.Second {
-		page.MustScreenshot("debug_loop.png")
-
-		// 1. Dismiss "Continue without audio or video"
-		page.MustEval(`() => {
-			const btns = Array.from(document.querySelectorAll('button'));
-			const continueBtn = btns.find(b => b.innerText.includes('Continue without audio'));
-			if (continueBtn) continueBtn.click();
-		}`)
-
-	// 2. Mute Microphone (Refined)
-		page.MustEval(`() => {
-			const switches = Array.from(document.querySelectorAll('input[role="switch"]'));
-			switches.forEach(s => {
-				const ariaLabel = (s.getAttribute('aria-label') || "").toLowerCase();
-				// Check for "Mic" in label. State check: 'checked' property OR 'aria-checked' attribute
-				const isActive = s.checked || s.getAttribute('aria-checked') === 'true';
-				
-				if (ariaLabel.includes('mic') && isActive) {
-					console.log("Muting microphone (Refined)...");
-					s.click();
-				}
-
-				// Disable Camera
-				if ((ariaLabel.includes('camera') || ariaLabel.includes('video')) && isActive) {
-					console.log("Disabling camera...");
-					s.click();
-				}
-			});
-		}`)
-
-		// 3. Name Input & Join
-		status := page.MustEval(`(name) => {
-			const inputs = Array.from(document.querySelectorAll('input'));
-			const nameInput = inputs.find(i => 
-				i.getAttribute('data-tid') === 'prejoin-display-name-input' || 
-				(i.placeholder && i.placeholder.toLowerCase().includes('type your name'))
-			);
-			
-			if (!nameInput) return "input_not_found";
-			
-			// REACT HACK: Use native value setter
-			const nativeInputValueSetter = Object.getOwnPropertyDescriptor(window.HTMLInputElement.prototype, "value").set;
-			nativeInputValueSetter.call(nameInput, name);
-			
-			nameInput.dispatchEvent(new Event('input', { bubbles: true }));
-			nameInput.dispatchEvent(new Event('change', { bubbles: true }));
-			nameInput.dispatchEvent(new Event('blur', { bubbles: true })); 
-			
-			const btns = Array.from(document.querySelectorAll('button'));
-			const joinBtn = btns.find(b => 
-				b.getAttribute('data-tid') === 'prejoin-join-button' ||
-				b.innerText === 'Join now' ||
-				b.getAttribute('aria-label') === 'Join now'
-			);
-			
-			if (joinBtn && !joinBtn.disabled) {
-				joinBtn.click();
-				return "joined";
+
+	// The flow gets a page bound to ctx so a stop aborts in-flight CDP calls;
+	// rod.Try turns the resulting Must* panics into an error
+	var joinErr error
+	err = rod.Try(func() {
+		joinErr = r.flow.Join(ctx, page.Context(ctx), session, locale, diag)
+	})
+	if err == nil {
+		err = joinErr
+	}
+	if err == nil {
+		err = r.awaitAdmission(ctx, session, locale, page.Context(ctx), diag)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			diag.close()
+			r.StopMeeting(context.Background(), session.ID)
+			return ctx.Err()
+		}
+		if identity != nil {
+			// A join that stalls on a sign-in page is an expired login, not a flow bug
+			if signedOut := r.checkSignedOut(page, locale); signedOut != nil {
+				err = signedOut
 			}
-			
-			return "waiting_for_join_button";
-		}`, session.ParticipantName).Str()
-		
-		if status == "joined" {
-			lobbyReached = true
-			fmt.Println("[Rod] Successfully triggered join via JS!")
-			break
 		}
-		
-		time.Sleep(2 * time.Second)
+		r.saveDiagnostics(session, page, diag, err)
+		r.StopMeeting(context.Background(), session.ID)
+		return err
+	}
+	diag.close()
+	if freshLogin {
+		markApplied(*identity, loginVersion)
 	}
 
-	if lobbyReached {
-		fmt.Println("[Rod] Join action triggered. Establishing monitoring...")
-		
-		// Start Auto-Stop Monitor
-		go r.monitorMeetingStatus(ctx, session.ID, page)
+	// Chat, live captions and the roster are best effort; the recording does not
+	// depend on them. They run one after the other because all of them click
+	// through the call controls.
+	go func() {
+		if chat, ok := r.flow.(chatFlow); ok {
+			r.startChat(ctx, session.ID, locale, page, chat)
+		}
+		if captions, ok := r.flow.(captionFlow); ok {
+			r.startCaptions(ctx, session.ID, locale, page, captions)
+		}
+		if roster, ok := r.flow.(rosterFlow); ok {
+			r.sampleRoster(ctx, session.ID, locale, page, roster)
+		}
+	}()
+
+	// Start Auto-Stop Monitor
+	go r.monitorMeetingStatus(ctx, session.ID, locale, browser, page, stop)
+	return nil
+}
+
+// newPage opens the tab the bot joins from, disguised as desktop Chrome on
+// Windows speaking the given languages.
+func (r *RodAdapter) newPage(browser *rod.Browser, languages []string) (*rod.Page, error) {
+	languagesJSON, _ := json.Marshal(languages)
+	page, err := browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = page.EvalOnNewDocument(`
+		const languages = ` + string(languagesJSON) + `;
+		const winUA = 'Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36';
+		Object.defineProperty(navigator, 'userAgent', { get: () => winUA });
+		Object.defineProperty(navigator, 'webdriver', { get: () => false });
+		Object.defineProperty(navigator, 'platform', { get: () => 'Win32' });
+		Object.defineProperty(navigator, 'vendor', { get: () => 'Google Inc.' });
+		Object.defineProperty(navigator, 'language', { get: () => languages[0] });
+		Object.defineProperty(navigator, 'languages', { get: () => languages });
+		Object.defineProperty(navigator, 'hardwareConcurrency', { get: () => 8 });
+		Object.defineProperty(navigator, 'deviceMemory', { get: () => 8 });
 		
-		time.Sleep(10 * time.Second)
-		return nil
+		window.chrome = { runtime: {} };
+		delete navigator.__proto__.webdriver;
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	err = page.SetViewport(&proto.EmulationSetDeviceMetricsOverride{Width: 1920, Height: 1080, DeviceScaleFactor: 1})
+	if err != nil {
+		return nil, err
+	}
+	err = page.SetUserAgent(&proto.NetworkSetUserAgentOverride{
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+		Platform:  "Windows",
+	})
+	if err != nil {
+		return nil, err
 	}
-	
-	return fmt.Errorf("failed to join meeting (JS could not complete flow) after 45 seconds")
+	// Both calls set the full header list, so they are made as one
+	_, err = page.SetExtraHeaders([]string{
+		"Accept-Language", acceptLanguage(languages),
+		"referer", r.flow.Referer(),
+		"sec-ch-ua-platform", "Windows",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
-func (r *RodAdapter) monitorMeetingStatus(ctx context.Context, sessionID string, page *rod.Page) {
+// monitorMeetingStatus watches for the bot leaving the meeting on its own:
+// the flow's exit screens, a crashed tab or a lost browser connection.
+func (r *RodAdapter) monitorMeetingStatus(ctx context.Context, sessionID, locale string, browser *rod.Browser, page *rod.Page, stop <-chan struct{}) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
+	crashed := make(chan struct{}, 1)
+	signalCrash := func() {
+		select {
+		case crashed <- struct{}{}:
+		default:
+		}
+	}
+	go page.EachEvent(func(e *proto.InspectorTargetCrashed) {
+		signalCrash()
+	})()
+	go func() {
+		// The event stream closes when the DevTools connection drops
+		for range browser.Event() {
+		}
+		signalCrash()
+	}()
+
 	fmt.Printf("[Rod] Monitoring session %s for exit conditions...\n", sessionID)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-r.stopCh[sessionID]:
+		case <-stop:
+			return
+		case <-crashed:
+			select {
+			case <-stop:
+				return // The browser went away because we closed it
+			default:
+			}
+			fmt.Printf("[Rod] Browser for session %s crashed or disconnected\n", sessionID)
+			r.meetingEnded(sessionID, domain.StopBrowserCrashed)
 			return
 		case <-ticker.C:
-			// Check for exit conditions (Broadened)
-			hasExited := false
-			err := rod.Try(func() {
-				hasExited = page.MustEval(`() => {
-					const bodyText = document.body.innerText;
-					return bodyText.includes("You have been removed") || 
-						   bodyText.includes("Someone removed you") ||
-						   bodyText.includes("Meeting ended") ||
-						   bodyText.includes("Call ended") ||
-						   bodyText.includes("Quality of this call") ||
-						   bodyText.includes("How was the quality");
-				}`).Bool()
-			})
-			
-			if err == nil && hasExited {
-				fmt.Printf("[Rod] Detected exit condition for session %s. Stopping...\n", sessionID)
-				r.StopMeeting(context.Background(), sessionID)
+			reason, err := r.flow.HasEnded(page, locale)
+			if err == nil && reason != "" {
+				fmt.Printf("[Rod] Detected exit condition for session %s (%s). Stopping...\n", sessionID, reason)
+				r.meetingEnded(sessionID, reason)
 				return
 			}
 		}
 	}
 }
 
+// checkSignedOut returns an error wrapping domain.ErrProfileSignedOut when
+// the page is asking the bot to sign in.
+func (r *RodAdapter) checkSignedOut(page *rod.Page, locale string) error {
+	signedOut, err := r.flow.SignedOut(page, locale)
+	if err != nil || !signedOut {
+		return nil
+	}
+	url := ""
+	if info, err := page.Info(); err == nil {
+		url = info.URL
+	}
+	return fmt.Errorf("%s redirected to sign-in (%s): %w", r.flow.Name(), url, domain.ErrProfileSignedOut)
+}
+
+// localeFor is the client language for session, falling back to the default.
+func (r *RodAdapter) localeFor(session *domain.MeetingSession) string {
+	if session.Locale != "" {
+		return normalizeLocale(session.Locale)
+	}
+	return r.locale
+}
+
+// meetingEnded hands the stop to the observer, which finalizes the recording
+// and closes the browser. Without one the browser is closed here.
+func (r *RodAdapter) meetingEnded(sessionID string, reason domain.StopReason) {
+	if observer := r.getObserver(); observer != nil {
+		observer.MeetingEnded(sessionID, reason)
+		return
+	}
+	r.StopMeeting(context.Background(), sessionID)
+}
+
+// saveDiagnostics bundles what the failed join collected next to the
+// session's recordings and reports it to the observer as an artifact.
+func (r *RodAdapter) saveDiagnostics(session *domain.MeetingSession, page *rod.Page, diag *diagnostics, joinErr error) {
+	path := filepath.Join(r.diagDir, fmt.Sprintf("meeting-%s-diagnostics.zip", session.ID))
+	if err := diag.write(path, page, session, r.flow.Name(), joinErr); err != nil {
+		log.Printf("[Rod] Failed to write diagnostics for session %s: %v", session.ID, err)
+		return
+	}
+	fmt.Printf("[Rod] Join diagnostics for session %s saved to %s\n", session.ID, path)
+	if observer := r.getObserver(); observer != nil {
+		observer.ArtifactCaptured(session.ID, domain.ArtifactDiagnostics, path)
+	}
+}
+
+// sleep waits for d unless ctx is cancelled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func (r *RodAdapter) SetObserver(observer ports.MeetingObserver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observer = observer
+}
+
+func (r *RodAdapter) getObserver() ports.MeetingObserver {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.observer
+}
+
 func (r *RodAdapter) StopMeeting(ctx context.Context, sessionID string) error {
 	log.Printf("StopMeeting called for session %s\n", sessionID)
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if sink, ok := r.sinks[sessionID]; ok {
+		if err := sink.Remove(); err != nil {
+			log.Printf("[Rod] %v", err)
+		}
+		delete(r.sinks, sessionID)
+	}
+
 	// Signal monitor to stop
 	if ch, ok := r.stopCh[sessionID]; ok {
 		close(ch)
 		delete(r.stopCh, sessionID)
 	}
 
+	var err error
 	if browser, ok := r.browsers[sessionID]; ok {
 		// Close browser
-		err := browser.Close()
+		err = browser.Close()
 		delete(r.browsers, sessionID)
 		delete(r.pages, sessionID)
-		return err
 	}
-	return nil
+
+	// Also reached when the browser crashed, via the observer's stop
+	if profile, ok := r.tempProfiles[sessionID]; ok {
+		delete(r.tempProfiles, sessionID)
+		go profile.discard()
+	}
+	return err
 }
 
 func (r *RodAdapter) GetSnapshot(ctx context.Context, sessionID string) ([]byte, error) {
@@ -295,50 +497,32 @@ func (r *RodAdapter) GetSnapshot(ctx context.Context, sessionID string) ([]byte,
 	return page.Screenshot(true, nil)
 }
 
-func (r *RodAdapter) GetMeetingStreams(ctx context.Context, sessionID string) (io.Reader, io.Reader, error) {
+func (r *RodAdapter) GetMeetingStreams(ctx context.Context, sessionID string) (<-chan domain.VideoFrame, io.Reader, error) {
 	r.mu.Lock()
 	page, ok := r.pages[sessionID]
+	sink := r.sinks[sessionID]
+	stop := r.stopCh[sessionID]
 	r.mu.Unlock()
 
 	if !ok {
 		return nil, nil, fmt.Errorf("page not found for session %s", sessionID)
 	}
 
-	// Create pipe for video stream
-	pr, pw := io.Pipe()
-
-	// Start a goroutine to capture screenshots and write to pipe
-	go func() {
-		defer pw.Close()
-		
-		// 5 FPS = 200ms interval
-		ticker := time.NewTicker(200 * time.Millisecond)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-r.stopCh[sessionID]:
-				return
-			case <-ticker.C:
-				// Capture Screenshot
-				// Note: Use Screenshot(true, nil) for PNG
-				buf, err := page.Screenshot(true, nil)
-				if err != nil {
-					log.Printf("[RodStream] Error capturing screenshot: %v", err)
-					return // Exit stream on error (browser probably closed)
-				}
-				
-				// Write to pipe
-				if _, err := pw.Write(buf); err != nil {
-					log.Printf("[RodStream] Error writing to pipe: %v", err)
-					return
-				}
-			}
+	var audio io.Reader
+	if sink != nil {
+		var err error
+		if audio, err = sink.Capture(); err != nil {
+			log.Printf("[RodStream] Recording without audio: %v", err)
 		}
-	}()
+	}
+
+	var frames <-chan domain.VideoFrame
+	switch r.capture.Mode {
+	case CaptureScreenshot:
+		frames = captureScreenshots(ctx, page, r.capture, stop)
+	default:
+		frames = captureScreencast(ctx, page, r.capture, stop)
+	}
 
-	// Return Video Stream (pr), Audio Stream (nil for now)
-	return pr, nil, nil
+	return frames, audio, nil
 }
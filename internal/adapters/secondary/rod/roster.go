@@ -0,0 +1,89 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// rosterFlow is implemented by flows that can list who is in the meeting.
+type rosterFlow interface {
+	// OpenRoster performs one step towards showing the participant list and
+	// reports whether it is now visible.
+	OpenRoster(page *rod.Page, locale string) (bool, error)
+	// Roster reads the display names from the visible participant list. It
+	// fails, rather than returning fewer names, when the list or one of its
+	// entries can't be read.
+	Roster(page *rod.Page, locale string) ([]string, error)
+}
+
+const (
+	rosterInterval     = 30 * time.Second
+	rosterOpenAttempts = 10
+)
+
+// sampleRoster reports the roster to the observer until the session stops.
+// A sample that could not be read is skipped rather than reported, since an
+// empty roster means the bot is alone.
+func (r *RodAdapter) sampleRoster(ctx context.Context, sessionID, locale string, page *rod.Page, flow rosterFlow) {
+	r.mu.Lock()
+	stop := r.stopCh[sessionID]
+	r.mu.Unlock()
+
+	ticker := time.NewTicker(rosterInterval)
+	defer ticker.Stop()
+
+	for {
+		names, err := r.readRoster(ctx, locale, page, flow)
+		if err != nil {
+			log.Printf("[RodRoster] Session %s: %v", sessionID, err)
+		} else if observer := r.getObserver(); observer != nil {
+			observer.RosterSampled(sessionID, names, time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *RodAdapter) readRoster(ctx context.Context, locale string, page *rod.Page, flow rosterFlow) ([]string, error) {
+	opened, err := retryStep(ctx, rosterOpenAttempts, func() (bool, error) {
+		return flow.OpenRoster(page, locale)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !opened {
+		return nil, fmt.Errorf("participant list could not be opened")
+	}
+	names, err := flow.Roster(page, locale)
+
+	// The roster and chat share one side panel, so put the chat back
+	if chat, ok := r.flow.(chatFlow); ok {
+		if _, err := retryStep(ctx, chatOpenAttempts, func() (bool, error) { return chat.OpenChat(page, locale) }); err != nil {
+			log.Printf("[RodRoster] Failed to reopen chat: %v", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(names))
+	out := names[:0]
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" && !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,433 @@
+package rod
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"gopkg.in/yaml.v3"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// Join flows are described by step scripts so selectors and UI labels can be
+// changed without a rebuild. Built-in scripts live in flows/; a file with the
+// same name in the flow directory replaces one and is reloaded when it changes.
+// UI text in other languages comes from the locale catalog (locale.go).
+//
+//go:embed flows/*.yaml
+var builtinScripts embed.FS
+
+const scriptVersion = 1
+
+const (
+	defaultJoinTimeout  = 60 * time.Second
+	defaultStepTimeout  = 10 * time.Second
+	defaultStepInterval = time.Second
+)
+
+// Step actions
+const (
+	actionClick           = "click"             // First enabled element matching selector
+	actionClickByText     = "click_by_text"     // First enabled element whose text or aria-label matches
+	actionFillInput       = "fill_input"        // Set value on the input matching selector
+	actionToggleOffSwitch = "toggle_off_switch" // Turn off every switch whose aria-label matches
+	actionWaitFor         = "wait_for"          // Selector or text is on the page
+	actionAssertText      = "assert_text"       // Like wait_for, but a failure names the missing text
+)
+
+// joinScript is one platform's versioned flow file.
+type joinScript struct {
+	Version int `yaml:"version"`
+	Join    struct {
+		Timeout time.Duration `yaml:"timeout"`
+		Steps   []scriptStep  `yaml:"steps"`
+	} `yaml:"join"`
+	// Admission and Ended are checked in order; the first rule that matches wins
+	Admission []admissionRule `yaml:"admission"`
+	Ended     []endedRule     `yaml:"ended"`
+	// SignedOut recognizes the sign-in page a profile's expired login lands on
+	SignedOut pageMatch `yaml:"signed_out"`
+}
+
+// Step conditions
+const (
+	whenGuest    = "guest"     // Only when joining without a profile
+	whenSignedIn = "signed_in" // Only when joining with a profile
+)
+
+type scriptStep struct {
+	Name     string        `yaml:"name"`
+	Action   string        `yaml:"action"`
+	Selector string        `yaml:"selector"`
+	Text     textList      `yaml:"text"`
+	Exact    bool          `yaml:"exact"` // Text must equal, not just contain
+	Value    string        `yaml:"value"` // fill_input; {{participantName}} is substituted
+	Optional bool          `yaml:"optional"`
+	When     string        `yaml:"when"`    // guest or signed_in; empty runs always
+	Retries  int           `yaml:"retries"` // Extra attempts; 0 retries until the timeout
+	Interval time.Duration `yaml:"interval"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// pageMatch is true when selector matches an element, the page text
+// contains any of Text or the page URL contains any of URL.
+type pageMatch struct {
+	Selector string   `yaml:"selector"`
+	Text     textList `yaml:"text"`
+	URL      textList `yaml:"url"`
+}
+
+type admissionRule struct {
+	State     admission `yaml:"state"`
+	pageMatch `yaml:",inline"`
+}
+
+type endedRule struct {
+	Reason    domain.StopReason `yaml:"reason"`
+	pageMatch `yaml:",inline"`
+}
+
+// textList accepts either a single string or a list.
+type textList []string
+
+func (t *textList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = textList{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+func parseJoinScript(data []byte) (*joinScript, error) {
+	var script joinScript
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, err
+	}
+	if script.Version != scriptVersion {
+		return nil, fmt.Errorf("unsupported script version %d (want %d)", script.Version, scriptVersion)
+	}
+	if len(script.Join.Steps) == 0 {
+		return nil, fmt.Errorf("join has no steps")
+	}
+	for i, step := range script.Join.Steps {
+		switch step.Action {
+		case actionClick, actionFillInput:
+			if step.Selector == "" {
+				return nil, fmt.Errorf("step %d (%s) needs a selector", i+1, step.Action)
+			}
+		case actionClickByText, actionToggleOffSwitch, actionAssertText:
+			if len(step.Text) == 0 {
+				return nil, fmt.Errorf("step %d (%s) needs text", i+1, step.Action)
+			}
+		case actionWaitFor:
+			if step.Selector == "" && len(step.Text) == 0 {
+				return nil, fmt.Errorf("step %d (%s) needs a selector or text", i+1, step.Action)
+			}
+		default:
+			return nil, fmt.Errorf("step %d: unknown action %q", i+1, step.Action)
+		}
+		switch step.When {
+		case "", whenGuest, whenSignedIn:
+		default:
+			return nil, fmt.Errorf("step %d: unknown condition %q", i+1, step.When)
+		}
+	}
+	for _, rule := range script.Admission {
+		switch rule.State {
+		case admissionLobby, admissionInMeeting, admissionDenied:
+		default:
+			return nil, fmt.Errorf("unknown admission state %q", rule.State)
+		}
+	}
+	return &script, nil
+}
+
+// scriptSource serves a platform's current script, reloading the override
+// file when its modification time changes. A broken override is logged and
+// the last good script stays in use.
+type scriptSource struct {
+	name    string // File name without extension, e.g. "teams"
+	dir     string
+	builtin *joinScript
+	locales *localeCatalog
+
+	mu       sync.Mutex
+	override *joinScript // Last good override, nil if none
+	path     string
+	modTime  time.Time
+}
+
+func newScriptSource(name, dir string) *scriptSource {
+	data, err := builtinScripts.ReadFile("flows/" + name + ".yaml")
+	if err != nil {
+		panic(fmt.Sprintf("missing built-in join flow %s: %v", name, err))
+	}
+	script, err := parseJoinScript(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in join flow %s: %v", name, err))
+	}
+	return &scriptSource{name: name, dir: dir, builtin: script, locales: newLocaleCatalog(dir)}
+}
+
+// current returns the script for a session whose client shows locale.
+func (s *scriptSource) current(locale string) *joinScript {
+	return s.load().localize(s.locales.phrases(locale, s.name))
+}
+
+// text returns the phrases and their translations for locale, for UI text
+// that is matched in code rather than by the script.
+func (s *scriptSource) text(locale string, phrases ...string) []string {
+	return localizeText(phrases, s.locales.phrases(locale, s.name))
+}
+
+// selector is text for a CSS selector list: quoted attribute values are
+// matched in locale too.
+func (s *scriptSource) selector(locale, selector string) string {
+	return localizeSelector(selector, s.locales.phrases(locale, s.name))
+}
+
+func (s *scriptSource) load() *joinScript {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, info := s.find()
+	if path == "" {
+		s.override, s.path = nil, ""
+		return s.builtin
+	}
+	if path != s.path || !info.ModTime().Equal(s.modTime) {
+		s.path, s.modTime = path, info.ModTime()
+		data, err := os.ReadFile(path)
+		var script *joinScript
+		if err == nil {
+			script, err = parseJoinScript(data)
+		}
+		if err != nil {
+			log.Printf("[RodScript] Ignoring %s: %v", path, err)
+		} else {
+			log.Printf("[RodScript] Loaded join flow from %s", path)
+			s.override = script
+		}
+	}
+	if s.override != nil {
+		return s.override
+	}
+	return s.builtin
+}
+
+// find returns the override file for this flow, if there is one.
+func (s *scriptSource) find() (string, os.FileInfo) {
+	if s.dir == "" {
+		return "", nil
+	}
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(s.dir, s.name+ext)
+		if info, err := os.Stat(path); err == nil {
+			return path, info
+		}
+	}
+	return "", nil
+}
+
+// runJoin executes the join steps in order, skipping those for the other
+// kind of join.
+func (s *joinScript) runJoin(ctx context.Context, page *rod.Page, session *domain.MeetingSession, signedIn bool, diag *diagnostics) error {
+	timeout := s.Join.Timeout
+	if timeout <= 0 {
+		timeout = defaultJoinTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	page = page.Context(ctx)
+
+	vars := strings.NewReplacer("{{participantName}}", session.ParticipantName, "{{meetingUrl}}", session.MeetingURL)
+	for i, step := range s.Join.Steps {
+		name := step.Name
+		if name == "" {
+			name = step.Action
+		}
+		if (step.When == whenGuest && signedIn) || (step.When == whenSignedIn && !signedIn) {
+			continue
+		}
+		diag.screenshot(page, fmt.Sprintf("step%02d-%s", i+1, name))
+
+		done, lastErr := step.run(ctx, page, vars)
+		if done {
+			fmt.Printf("[RodScript] Step %d (%s) done\n", i+1, name)
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return fmt.Errorf("join flow timed out after %s at step %d (%s)", timeout, i+1, name)
+			}
+			return err
+		}
+		if step.Optional {
+			fmt.Printf("[RodScript] Optional step %d (%s) skipped\n", i+1, name)
+			continue
+		}
+		if step.Action == actionAssertText {
+			return fmt.Errorf("step %d (%s): none of %q found on the page", i+1, name, []string(step.Text))
+		}
+		if lastErr != nil {
+			return fmt.Errorf("step %d (%s) failed: %w", i+1, name, lastErr)
+		}
+		return fmt.Errorf("step %d (%s) did not complete", i+1, name)
+	}
+	return nil
+}
+
+// run attempts the step until it succeeds, runs out of attempts or times out.
+func (step scriptStep) run(ctx context.Context, page *rod.Page, vars *strings.Replacer) (bool, error) {
+	timeout, interval := step.Timeout, step.Interval
+	if timeout <= 0 {
+		timeout = defaultStepTimeout
+	}
+	if interval <= 0 {
+		interval = defaultStepInterval
+	}
+	deadline := time.Now().Add(timeout)
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		done := false
+		lastErr = rod.Try(func() {
+			done = page.MustEval(scriptStepJS,
+				step.Action, step.Selector, []string(step.Text), step.Exact, vars.Replace(step.Value)).Bool()
+		})
+		if done {
+			return true, nil
+		}
+		if (step.Retries > 0 && attempt >= step.Retries) || !time.Now().Add(interval).Before(deadline) {
+			return false, lastErr
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return false, lastErr
+		}
+	}
+}
+
+// admission classifies the page with the first matching rule.
+func (s *joinScript) admission(page *rod.Page) (admission, error) {
+	for _, rule := range s.Admission {
+		ok, err := rule.matches(page)
+		if err != nil {
+			return admissionPending, err
+		}
+		if ok {
+			return rule.State, nil
+		}
+	}
+	return admissionPending, nil
+}
+
+// ended reports the reason of the first matching rule, or "".
+func (s *joinScript) ended(page *rod.Page) (domain.StopReason, error) {
+	for _, rule := range s.Ended {
+		ok, err := rule.matches(page)
+		if err != nil || ok {
+			return rule.Reason, err
+		}
+	}
+	return "", nil
+}
+
+// signedOut reports whether the page is a sign-in screen.
+func (s *joinScript) signedOut(page *rod.Page) (bool, error) {
+	return s.SignedOut.matches(page)
+}
+
+func (m pageMatch) matches(page *rod.Page) (bool, error) {
+	if len(m.URL) > 0 {
+		info, err := page.Info()
+		if err != nil {
+			return false, err
+		}
+		for _, part := range m.URL {
+			if strings.Contains(info.URL, part) {
+				return true, nil
+			}
+		}
+	}
+	if m.Selector == "" && len(m.Text) == 0 {
+		return false, nil
+	}
+	found := false
+	err := rod.Try(func() {
+		found = page.MustEval(scriptStepJS, actionWaitFor, m.Selector, []string(m.Text), false, "").Bool()
+	})
+	return found, err
+}
+
+// scriptStepJS performs one attempt of a step and reports whether it is done.
+const scriptStepJS = `(action, selector, texts, exact, value) => {
+	texts = texts || [];
+	const norm = s => (s || "").replace(/\s+/g, " ").trim();
+	const matches = e => {
+		const t = [norm(e.innerText), norm(e.getAttribute('aria-label'))];
+		return texts.some(x => exact ? t.includes(x) : t.some(v => v.toLowerCase().includes(x.toLowerCase())));
+	};
+	const enabled = e => !e.disabled && e.getAttribute('aria-disabled') !== 'true';
+	const visible = e => !!(e.offsetWidth || e.offsetHeight || e.getClientRects().length);
+	const all = sel => Array.from(document.querySelectorAll(sel));
+
+	switch (action) {
+	case "click": {
+		const el = all(selector).find(e => enabled(e) && visible(e));
+		if (!el) return false;
+		el.click();
+		return true;
+	}
+	case "click_by_text": {
+		const el = all(selector || 'button, [role="button"], [role="menuitem"], a').find(e => enabled(e) && visible(e) && matches(e));
+		if (!el) return false;
+		el.click();
+		return true;
+	}
+	case "fill_input": {
+		const input = all(selector).find(visible);
+		if (!input) return false;
+		if (input.value !== value) {
+			// React ignores plain assignment; go through the native setter
+			const setter = Object.getOwnPropertyDescriptor(window.HTMLInputElement.prototype, "value").set;
+			setter.call(input, value);
+			input.dispatchEvent(new Event('input', { bubbles: true }));
+			input.dispatchEvent(new Event('change', { bubbles: true }));
+			input.dispatchEvent(new Event('blur', { bubbles: true }));
+		}
+		return true;
+	}
+	case "toggle_off_switch": {
+		const isOn = e => e.checked === true || e.getAttribute('aria-checked') === 'true' ||
+			e.getAttribute('aria-pressed') === 'true' || e.getAttribute('data-is-muted') === 'false';
+		const switches = all(selector || 'input[role="switch"], [role="switch"], [data-is-muted]').filter(e => {
+			const l = (e.getAttribute('aria-label') || "").toLowerCase();
+			return texts.some(x => l.includes(x.toLowerCase()));
+		});
+		if (switches.length === 0) return false;
+		switches.filter(isOn).forEach(e => e.click());
+		return true;
+	}
+	case "wait_for":
+	case "assert_text": {
+		if (selector && document.querySelector(selector)) return true;
+		const body = norm(document.body && document.body.innerText).toLowerCase();
+		return texts.some(x => body.includes(x.toLowerCase()));
+	}
+	}
+	throw new Error("unknown action " + action);
+}`
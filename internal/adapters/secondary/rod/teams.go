@@ -0,0 +1,283 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// teamsFlow takes its join steps and screen detection from the teams script;
+// captions, chat and the roster are driven in code.
+type teamsFlow struct {
+	script *scriptSource
+}
+
+func NewTeamsAutomator(cfg Config) ports.BrowserAutomator {
+	return newRodAdapter(teamsFlow{script: newScriptSource("teams", cfg.FlowDir)}, cfg)
+}
+
+func (teamsFlow) Name() string { return "Teams" }
+
+func (teamsFlow) Referer() string { return "https://teams.live.com/" }
+
+// MeetingURL sends guests straight to the anonymous web join; a signed-in bot
+// opens the link as given so it joins as its account.
+func (teamsFlow) MeetingURL(raw string, signedIn bool) string {
+	finalURL := raw
+	if !signedIn && strings.Contains(finalURL, "teams.live.com/meet/") {
+		parts := strings.Split(finalURL, "teams.live.com/meet/")
+		if len(parts) > 1 {
+			remaining := parts[1]
+			meetingID := strings.Split(remaining, "?")[0]
+			pVal := ""
+			if strings.Contains(remaining, "p=") {
+				pParts := strings.Split(remaining, "p=")
+				if len(pParts) > 1 {
+					pVal = strings.Split(pParts[1], "&")[0]
+				}
+			}
+			finalURL = fmt.Sprintf("https://teams.live.com/_#/meet/%s?p=%s&anon=true", meetingID, pVal)
+			fmt.Printf("[Rod] Forced Deep Link URL: %s\n", finalURL)
+		}
+	}
+	return finalURL
+}
+
+func (f teamsFlow) Join(ctx context.Context, page *rod.Page, session *domain.MeetingSession, locale string, diag *diagnostics) error {
+	fmt.Println("[Rod] Handling Teams join flow...")
+	return f.script.current(locale).runJoin(ctx, page, session, session.ProfileID != "", diag)
+}
+
+func (f teamsFlow) Admission(page *rod.Page, locale string) (admission, error) {
+	return f.script.current(locale).admission(page)
+}
+
+func (f teamsFlow) HasEnded(page *rod.Page, locale string) (domain.StopReason, error) {
+	return f.script.current(locale).ended(page)
+}
+
+func (f teamsFlow) SignedOut(page *rod.Page, locale string) (bool, error) {
+	return f.script.current(locale).signedOut(page)
+}
+
+// The Teams call controls are found by aria-label and menu text, so these
+// selectors and phrases are localized through the flow's locale catalog like
+// the join steps.
+const (
+	teamsMoreButton   = `#callingButtons-showMoreBtn, button[data-tid="more-button"], button[aria-label="More"]`
+	teamsChatPane     = `[data-tid="message-pane-list-viewport"], [data-tid="chat-pane-list"]`
+	teamsChatButton   = `#chat-button, button[data-tid="chat-button"], button[aria-label="Chat"], button[aria-label^="Show conversation"]`
+	teamsRosterList   = `[data-tid="people-pane-list"], [aria-label="Participants"] [role="tree"]`
+	teamsRosterButton = `#roster-button, button[data-tid="roster-button"], button[aria-label="People"], button[aria-label^="Show participants"]`
+	teamsSelfLabel    = "(You)" // Suffix on the bot's own roster entry
+)
+
+// EnableCaptions walks the More menu one click per call until the caption pane shows.
+func (f teamsFlow) EnableCaptions(page *rod.Page, locale string) (bool, error) {
+	state := ""
+	err := rod.Try(func() {
+		state = page.MustEval(`(toggleLabels, submenuLabels, moreSel) => {
+			if (document.querySelector('[data-tid="closed-caption-v2-window-wrapper"], [data-tid="closed-captions-renderer"]')) return "on";
+
+			const find = (selector, labels) => Array.from(document.querySelectorAll(selector)).find(e => {
+				const t = ((e.innerText || "") + " " + (e.getAttribute('aria-label') || "")).trim();
+				return labels.some(l => t.includes(l));
+			});
+
+			// Menu path: More > Language and speech > Turn on live captions
+			const toggle = find('[role="menuitem"], [role="menuitemcheckbox"], button', toggleLabels);
+			if (toggle) { toggle.click(); return "clicked"; }
+			const submenu = find('[role="menuitem"]', submenuLabels);
+			if (submenu) { submenu.click(); return "submenu"; }
+			const more = document.querySelector(moreSel);
+			if (more) { more.click(); return "menu"; }
+			return "not_found";
+		}`,
+			f.script.text(locale, "Turn on live captions", "Show live captions"),
+			f.script.text(locale, "Language and speech"),
+			f.script.selector(locale, teamsMoreButton),
+		).Str()
+	})
+	return state == "on", err
+}
+
+// CaptionWatcherJS tracks each caption item until its text has been stable
+// for a while (Teams rewrites the line as recognition improves) or it scrolls
+// out of the container, then emits it once.
+func (teamsFlow) CaptionWatcherJS() string {
+	return `(emit) => {
+		if (window.__mmCaptionWatcher) return;
+		window.__mmCaptionWatcher = true;
+
+		const STABLE_MS = 2000;
+		const containerSel = '[data-tid="closed-caption-v2-window-wrapper"], [data-tid="closed-captions-renderer"]';
+		const tracked = new Map();
+
+		const flush = (rec) => {
+			if (rec.emitted || !rec.text.trim()) return;
+			rec.emitted = true;
+			emit({ speaker: rec.speaker, text: rec.text, start: rec.start, end: rec.changed });
+		};
+
+		const scan = () => {
+			const container = document.querySelector(containerSel);
+			const seen = new Set();
+			const now = Date.now();
+			if (container) {
+				container.querySelectorAll('.fui-ChatMessageCompact, [data-tid="closed-caption-message"]').forEach(el => {
+					seen.add(el);
+					const speaker = (el.querySelector('[data-tid="author"]') || {}).innerText || "";
+					const text = (el.querySelector('[data-tid="closed-caption-text"]') || {}).innerText || "";
+					const rec = tracked.get(el);
+					if (!rec) {
+						tracked.set(el, { speaker, text, start: now, changed: now, emitted: false });
+					} else if (!rec.emitted && rec.text !== text) {
+						rec.text = text;
+						rec.speaker = speaker || rec.speaker;
+						rec.changed = now;
+					}
+				});
+			}
+			for (const [el, rec] of tracked) {
+				if (!seen.has(el)) {
+					flush(rec);
+					tracked.delete(el);
+				} else if (now - rec.changed >= STABLE_MS) {
+					flush(rec);
+				}
+			}
+		};
+
+		let observed = null;
+		const observer = new MutationObserver(scan);
+		setInterval(() => {
+			// Teams re-renders the caption pane, so re-attach when it is replaced
+			const container = document.querySelector(containerSel);
+			if (container && container !== observed) {
+				observer.disconnect();
+				observer.observe(container, { childList: true, subtree: true, characterData: true });
+				observed = container;
+			}
+			scan();
+		}, 500);
+	}`
+}
+
+// OpenChat clicks the Chat button in the call controls until the chat pane shows.
+func (f teamsFlow) OpenChat(page *rod.Page, locale string) (bool, error) {
+	state := ""
+	err := rod.Try(func() {
+		state = page.MustEval(`(paneSel, buttonSel) => {
+			if (document.querySelector(paneSel)) return "open";
+			const btn = document.querySelector(buttonSel);
+			if (btn) { btn.click(); return "clicked"; }
+			return "not_found";
+		}`, teamsChatPane, f.script.selector(locale, teamsChatButton)).Str()
+	})
+	return state == "open", err
+}
+
+// ChatWatcherJS emits each chat message once, keyed by its message id so
+// re-renders and scrolling do not produce duplicates.
+func (teamsFlow) ChatWatcherJS() string {
+	return `(emit) => {
+		if (window.__mmChatWatcher) return;
+		window.__mmChatWatcher = true;
+
+		const listSel = '[data-tid="message-pane-list-viewport"], [data-tid="chat-pane-list"]';
+		const seen = new Set();
+		let lastSender = "";
+
+		const scan = () => {
+			const list = document.querySelector(listSel);
+			if (!list) return;
+			list.querySelectorAll('[data-tid="chat-pane-message"], [data-tid="chat-pane-item"]').forEach(el => {
+				const id = el.getAttribute('data-mid') || el.id || (el.querySelector('[id^="content-"]') || {}).id;
+				if (!id || seen.has(id)) return;
+
+				const body = el.querySelector('[id^="content-"], [data-tid="chat-pane-message-body"]');
+				if (!body) return;
+				seen.add(id);
+
+				// Consecutive messages from one person omit the author line
+				const author = el.querySelector('[data-tid="message-author-name"]');
+				const sender = author ? author.innerText.trim() : lastSender;
+				lastSender = sender;
+
+				const stamp = el.querySelector('time[datetime]');
+				const time = (stamp && Date.parse(stamp.getAttribute('datetime'))) || Date.now();
+				const links = Array.from(body.querySelectorAll('a[href]')).map(a => a.href);
+
+				emit({ sender, text: body.innerText || "", links, time });
+			});
+		};
+
+		let observed = null;
+		const observer = new MutationObserver(scan);
+		setInterval(() => {
+			const list = document.querySelector(listSel);
+			if (list && list !== observed) {
+				observer.disconnect();
+				observer.observe(list, { childList: true, subtree: true });
+				observed = list;
+			}
+			scan();
+		}, 1000);
+	}`
+}
+
+// OpenRoster clicks the People button in the call controls until the participant list shows.
+func (f teamsFlow) OpenRoster(page *rod.Page, locale string) (bool, error) {
+	state := ""
+	err := rod.Try(func() {
+		state = page.MustEval(`(listSel, buttonSel) => {
+			if (document.querySelector(listSel)) return "open";
+			const btn = document.querySelector(buttonSel);
+			if (btn) { btn.click(); return "clicked"; }
+			return "not_found";
+		}`, f.script.selector(locale, teamsRosterList), f.script.selector(locale, teamsRosterButton)).Str()
+	})
+	return state == "open", err
+}
+
+// Roster lists the names in the People pane, leaving out the bot itself
+// and the "(Guest)"/"(Unverified)" tags Teams appends to some names.
+func (f teamsFlow) Roster(page *rod.Page, locale string) ([]string, error) {
+	var found bool
+	var unreadable int
+	var names []string
+	err := rod.Try(func() {
+		res := page.MustEval(`(listSel, selfLabels) => {
+			const list = document.querySelector(listSel);
+			if (!list) return { found: false };
+			const names = [];
+			let unreadable = 0;
+			list.querySelectorAll('[data-tid^="participantsInCall-"], [role="treeitem"][data-cid]').forEach(item => {
+				const name = ((item.querySelector('[data-tid="roster-participant-name"], span[title]') || item).innerText || "").split('\n')[0].trim();
+				if (!name) { unreadable++; return; }
+				if (selfLabels.some(label => name.endsWith(label))) return;
+				names.push(name.replace(/\s*\((Guest|Unverified|External)\)\s*$/i, '').trim());
+			});
+			return { found: true, unreadable, names };
+		}`, f.script.selector(locale, teamsRosterList), f.script.text(locale, teamsSelfLabel))
+		found = res.Get("found").Bool()
+		unreadable = res.Get("unreadable").Int()
+		for _, v := range res.Get("names").Arr() {
+			names = append(names, v.Str())
+		}
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case !found:
+		return nil, fmt.Errorf("participant list not found")
+	case unreadable > 0:
+		return nil, fmt.Errorf("%d participant list entries could not be read", unreadable)
+	}
+	return names, nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// Signature scheme: X-Webhook-Signature is "t=<unix seconds>,v1=<hex>", where
+// v1 = HMAC-SHA256(secret, "<t>.<raw body>"). Receivers should recompute v1 and
+// reject stale timestamps to prevent replays. Without a secret the header is
+// left out rather than signed with an empty key.
+const (
+	headerSignature = "X-Webhook-Signature"
+	headerEvent     = "X-Webhook-Event"
+	headerDelivery  = "X-Webhook-Delivery"
+)
+
+type httpSender struct {
+	client *http.Client
+	secret []byte
+}
+
+func NewHTTPSender(secret string) ports.WebhookSender {
+	return &httpSender{
+		client: &http.Client{Timeout: 10 * time.Second},
+		secret: []byte(secret),
+	}
+}
+
+func (s *httpSender) Send(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-meeting-recorder-webhooks")
+	req.Header.Set(headerEvent, string(delivery.EventType))
+	req.Header.Set(headerDelivery, delivery.ID)
+	if len(s.secret) > 0 {
+		req.Header.Set(headerSignature, Sign(s.secret, time.Now(), delivery.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the X-Webhook-Signature header value for body sent at t.
+func Sign(secret []byte, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,110 @@
+package whisper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type Config struct {
+	Binary   string // whisper.cpp CLI, e.g. "whisper-cli" (older builds ship it as "main")
+	Model    string // Path to a ggml model file
+	Language string // ISO code, or "auto"
+	Threads  int
+}
+
+// transcriber shells out to a local whisper.cpp build. whisper.cpp only
+// accepts 16kHz mono WAV, so the recording is resampled with ffmpeg first.
+type transcriber struct {
+	cfg Config
+}
+
+func NewTranscriber(cfg Config) ports.Transcriber {
+	if cfg.Binary == "" {
+		cfg.Binary = "whisper-cli"
+	}
+	if cfg.Language == "" {
+		cfg.Language = "auto"
+	}
+	return &transcriber{cfg: cfg}
+}
+
+// whisperOutput is the subset of whisper.cpp's -oj output we use.
+type whisperOutput struct {
+	Transcription []struct {
+		Offsets struct {
+			From int64 `json:"from"` // milliseconds
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text string `json:"text"`
+	} `json:"transcription"`
+}
+
+func (t *transcriber) Transcribe(ctx context.Context, audioPath string) ([]domain.TranscriptSegment, error) {
+	workDir, err := os.MkdirTemp("", "whisper-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	input := filepath.Join(workDir, "input.wav")
+	resample := exec.CommandContext(ctx, "ffmpeg", "-y", "-i", audioPath, "-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", input)
+	if out, err := resample.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to resample audio: %w: %s", err, lastLine(out))
+	}
+
+	outPrefix := filepath.Join(workDir, "transcript")
+	args := []string{
+		"-m", t.cfg.Model,
+		"-f", input,
+		"-l", t.cfg.Language,
+		"-oj", "-of", outPrefix,
+		"-np", // no progress output
+	}
+	if t.cfg.Threads > 0 {
+		args = append(args, "-t", strconv.Itoa(t.cfg.Threads))
+	}
+
+	fmt.Printf("[Whisper] Running %s on %s\n", t.cfg.Binary, audioPath)
+	if out, err := exec.CommandContext(ctx, t.cfg.Binary, args...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", t.cfg.Binary, err, lastLine(out))
+	}
+
+	raw, err := os.ReadFile(outPrefix + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("whisper produced no output: %w", err)
+	}
+
+	var parsed whisperOutput
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse whisper output: %w", err)
+	}
+
+	segments := make([]domain.TranscriptSegment, 0, len(parsed.Transcription))
+	for _, seg := range parsed.Transcription {
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		segments = append(segments, domain.TranscriptSegment{
+			Start: time.Duration(seg.Offsets.From) * time.Millisecond,
+			End:   time.Duration(seg.Offsets.To) * time.Millisecond,
+			Text:  text,
+		})
+	}
+	return segments, nil
+}
+
+func lastLine(out []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "errors"
+
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// ArtifactKind names a file produced for a session.
+type ArtifactKind string
+
+const (
+	ArtifactRecording   ArtifactKind = "recording"   // Muxed audio/video MP4
+	ArtifactAudio       ArtifactKind = "audio"       // Audio-only WAV
+	ArtifactDiagnostics ArtifactKind = "diagnostics" // Zipped screenshots, DOM, console and HAR of a failed join
+)
+
+// Artifacts maps each produced file to its path on disk.
+type Artifacts map[ArtifactKind]string
+
+// AddArtifacts records files produced for the session, keeping any it already has.
+func (s *MeetingSession) AddArtifacts(artifacts Artifacts) {
+	if len(artifacts) == 0 {
+		return
+	}
+	if s.Artifacts == nil {
+		s.Artifacts = make(Artifacts, len(artifacts))
+	}
+	for kind, path := range artifacts {
+		s.Artifacts[kind] = path
+	}
+	s.FilePath = s.Artifacts[ArtifactRecording]
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+type PresenceEventType string
+
+const (
+	PresenceJoined PresenceEventType = "joined"
+	PresenceLeft   PresenceEventType = "left"
+)
+
+// PresenceEvent is one entry on a session's attendance timeline.
+type PresenceEvent struct {
+	Participant string            `json:"participant"`
+	Type        PresenceEventType `json:"type"`
+	Time        time.Time         `json:"time"`
+}
+
+// AttendanceRecord summarizes one participant's presence.
+type AttendanceRecord struct {
+	Name          string    `json:"name"`
+	FirstSeen     time.Time `json:"firstSeen"`
+	LastSeen      time.Time `json:"lastSeen"`
+	TotalPresence string    `json:"totalPresence"`
+	TotalSeconds  int64     `json:"totalSeconds"`
+}
+
+// PresentParticipants replays the timeline and returns who is currently in the meeting.
+func PresentParticipants(events []PresenceEvent) map[string]bool {
+	present := make(map[string]bool)
+	for _, e := range events {
+		switch e.Type {
+		case PresenceJoined:
+			present[e.Participant] = true
+		case PresenceLeft:
+			delete(present, e.Participant)
+		}
+	}
+	return present
+}
+
+// Attendance folds the presence timeline into one record per participant,
+// ordered by arrival. Anyone still present is counted until end.
+func Attendance(events []PresenceEvent, end time.Time) []AttendanceRecord {
+	type tally struct {
+		first, last time.Time
+		joinedAt    *time.Time
+		total       time.Duration
+	}
+	tallies := make(map[string]*tally)
+	for _, e := range events {
+		t, ok := tallies[e.Participant]
+		if !ok {
+			t = &tally{first: e.Time}
+			tallies[e.Participant] = t
+		}
+		switch e.Type {
+		case PresenceJoined:
+			if t.joinedAt == nil {
+				at := e.Time
+				t.joinedAt = &at
+			}
+			t.last = e.Time
+		case PresenceLeft:
+			if t.joinedAt != nil {
+				t.total += e.Time.Sub(*t.joinedAt)
+				t.joinedAt = nil
+			}
+			t.last = e.Time
+		}
+	}
+
+	records := make([]AttendanceRecord, 0, len(tallies))
+	for name, t := range tallies {
+		if t.joinedAt != nil && end.After(*t.joinedAt) {
+			t.total += end.Sub(*t.joinedAt)
+			t.last = end
+		}
+		total := t.total.Round(time.Second)
+		records = append(records, AttendanceRecord{
+			Name:          name,
+			FirstSeen:     t.first,
+			LastSeen:      t.last,
+			TotalPresence: total.String(),
+			TotalSeconds:  int64(total / time.Second),
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].FirstSeen.Equal(records[j].FirstSeen) {
+			return records[i].FirstSeen.Before(records[j].FirstSeen)
+		}
+		return records[i].Name < records[j].Name
+	})
+	return records
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCalendarNotFound = errors.New("calendar not found")
+
+// CalendarEvent is one occurrence of a VEVENT after recurrence expansion.
+type CalendarEvent struct {
+	UID         string // Shared by every occurrence of a recurring meeting
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	TimeZone    string // IANA name the start was expressed in
+}
+
+// CalendarOptions control how imported events become schedules.
+type CalendarOptions struct {
+	ParticipantName string
+	CallbackURLs    []string
+	JoinLeadMinutes int
+}
+
+// CalendarSubscription is an ICS feed polled for new and changed meetings.
+type CalendarSubscription struct {
+	ID              string     `json:"id"`
+	URL             string     `json:"url"`
+	ParticipantName string     `json:"participantName"`
+	CallbackURLs    []string   `json:"callbackUrls,omitempty"`
+	JoinLeadMinutes int        `json:"joinLeadMinutes"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	LastSyncAt      *time.Time `json:"lastSyncAt,omitempty"`
+	LastError       string     `json:"lastError,omitempty"`
+}
+
+func (c *CalendarSubscription) Options() CalendarOptions {
+	return CalendarOptions{
+		ParticipantName: c.ParticipantName,
+		CallbackURLs:    c.CallbackURLs,
+		JoinLeadMinutes: c.JoinLeadMinutes,
+	}
+}
+
+// CalendarImport reports what one import or sync did.
+type CalendarImport struct {
+	Created   []*Schedule `json:"created"`
+	Cancelled []*Schedule `json:"cancelled"`
+	Skipped   []string    `json:"skipped"` // Events without a recordable meeting link, by summary
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Caption is one finalized line of the platform's live captions.
+type Caption struct {
+	Speaker string
+	Text    string
+	Start   time.Time // When the line first appeared
+	End     time.Time // When its text last changed
+}
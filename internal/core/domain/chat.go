@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// ChatMessage is one message posted in the meeting chat.
+type ChatMessage struct {
+	Sender string    `json:"sender"`
+	Text   string    `json:"text"`
+	Links  []string  `json:"links,omitempty"`
+	Time   time.Time `json:"time"` // As shown by the platform when available, else when first seen
+}
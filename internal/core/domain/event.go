@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventStatusChanged    EventType = "session.status_changed"
+	EventError            EventType = "session.error"
+	EventDurationTick     EventType = "session.duration"
+	EventAutoStopDetected EventType = "session.auto_stop_detected"
+	EventTranscriptReady  EventType = "session.transcript_ready"
+	EventTranscriptFailed EventType = "session.transcript_failed"
+	EventMinutesReady     EventType = "session.minutes_ready"
+	EventMinutesFailed    EventType = "session.minutes_failed"
+)
+
+// Event is published whenever something observable happens to a session.
+// Only the fields relevant to Type are set.
+type Event struct {
+	ID        uint64        `json:"id"` // Monotonic per process
+	Type      EventType     `json:"type"`
+	SessionID string        `json:"sessionId"`
+	Time      time.Time     `json:"time"`
+	Status    SessionStatus `json:"status,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Duration  string        `json:"duration,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+}
+
+// EndsSession reports whether the event took its session out of the active
+// statuses. A failure arrives as EventError rather than EventStatusChanged.
+func (e Event) EndsSession() bool {
+	return e.Status != "" && !e.Status.IsActive()
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid session status transition")
+	// ErrAdmissionDenied is returned by a join when a host refuses entry
+	ErrAdmissionDenied = errors.New("admission to the meeting was denied")
+	// ErrLobbyTimeout is returned by a join when nobody admits the bot in time
+	ErrLobbyTimeout = errors.New("not admitted from the lobby in time")
+)
+
+// transitions lists the statuses each status may move to. Terminal statuses
+// (stopped, cancelled, denied, error, interrupted) have no entry.
+var transitions = map[SessionStatus][]SessionStatus{
+	StatusInitializing: {StatusJoining, StatusStopping, StatusCancelled, StatusError, StatusInterrupted},
+	StatusJoining:      {StatusInLobby, StatusAdmitted, StatusDenied, StatusStopping, StatusCancelled, StatusError, StatusInterrupted},
+	StatusInLobby:      {StatusAdmitted, StatusDenied, StatusStopping, StatusCancelled, StatusError, StatusInterrupted},
+	StatusAdmitted:     {StatusRecording, StatusStopping, StatusError, StatusInterrupted},
+	StatusRecording:    {StatusStopping, StatusError, StatusInterrupted},
+	StatusStopping:     {StatusStopped, StatusCancelled, StatusError, StatusInterrupted},
+}
+
+// CanTransition reports whether a session may move from s to next.
+func (s SessionStatus) CanTransition(next SessionStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the session to next, rejecting moves the lifecycle does not allow.
+func (s *MeetingSession) Transition(next SessionStatus) error {
+	if !s.Status.CanTransition(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, s.Status, next)
+	}
+	s.Status = next
+	return nil
+}
+
+// StopReason records why a session left the meeting.
+type StopReason string
+
+const (
+	StopRequested      StopReason = "requested" // Stop API call or schedule deadline
+	StopShutdown       StopReason = "shutdown"  // The service was terminated
+	StopMeetingEnded   StopReason = "meeting_ended"
+	StopRemoved        StopReason = "removed" // The bot was kicked from the meeting
+	StopBrowserCrashed StopReason = "browser_crashed"
+
+	// Limits from the session's StopPolicy
+	StopAlone       StopReason = "alone"
+	StopSilence     StopReason = "silence"
+	StopMaxDuration StopReason = "max_duration"
+)
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type FrameFormat string
+
+const (
+	FrameFormatJPEG FrameFormat = "jpeg"
+	FrameFormatPNG  FrameFormat = "png"
+)
+
+// VideoFrame is one encoded image of the meeting page. Timestamp is the
+// wall-clock capture time, so the encoder can reproduce real pacing.
+type VideoFrame struct {
+	Data      []byte
+	Format    FrameFormat
+	Timestamp time.Time
+}
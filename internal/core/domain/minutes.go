@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrMinutesNotReady = errors.New("minutes not ready")
+
+type ActionItem struct {
+	Description string `json:"description"`
+	Owner       string `json:"owner,omitempty"`
+	DueDate     string `json:"dueDate,omitempty"` // As stated in the meeting, e.g. "Friday" or "2024-06-01"
+}
+
+// Minutes is the structured outcome of a meeting.
+type Minutes struct {
+	Summary       string       `json:"summary"`
+	Decisions     []string     `json:"decisions"`
+	ActionItems   []ActionItem `json:"actionItems"`
+	OpenQuestions []string     `json:"openQuestions"`
+	GeneratedBy   string       `json:"generatedBy"` // Summarizer that produced these minutes
+	GeneratedAt   time.Time    `json:"generatedAt"`
+}
+
+// MinutesInput is everything a Summarizer may draw on.
+type MinutesInput struct {
+	MeetingURL      string
+	Platform        Platform
+	StartTime       *time.Time
+	Duration        string
+	ParticipantName string // The bot's display name, not an attendee
+	Transcript      []TranscriptSegment
+	Chat            []ChatMessage
+}
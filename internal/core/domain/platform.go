@@ -0,0 +1,27 @@
+package domain
+
+import "fmt"
+
+type Platform string
+
+const (
+	PlatformTeams Platform = "teams"
+	PlatformMeet  Platform = "meet"
+	PlatformZoom  Platform = "zoom"
+	PlatformJitsi Platform = "jitsi"
+)
+
+// UnsupportedMeetingError is returned when a meeting URL cannot be routed to an automator.
+// Platform is set when the URL was recognised but no automator is registered for it.
+type UnsupportedMeetingError struct {
+	URL      string
+	Platform Platform
+	Reason   string
+}
+
+func (e *UnsupportedMeetingError) Error() string {
+	if e.Platform != "" {
+		return fmt.Sprintf("unsupported meeting url %q (%s): %s", e.URL, e.Platform, e.Reason)
+	}
+	return fmt.Sprintf("unsupported meeting url %q: %s", e.URL, e.Reason)
+}
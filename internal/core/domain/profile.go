@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrProfileNotFound  = errors.New("bot profile not found")
+	ErrProfileSignedOut = errors.New("bot profile is not signed in")
+	ErrProfileBusy      = errors.New("bot profile is in use by another session")
+)
+
+type ProfileStatus string
+
+const (
+	ProfileNew     ProfileStatus = "new"     // No login uploaded yet
+	ProfileActive  ProfileStatus = "active"  // Has a login that has not been seen to fail
+	ProfileExpired ProfileStatus = "expired" // A join landed on a sign-in page; upload a fresh login
+)
+
+// BotProfile is a signed-in identity the bot can join meetings as. The login
+// itself (browser data and uploaded storage state) is kept by the automator,
+// not in this record.
+type BotProfile struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Platform       Platform      `json:"platform"`
+	Status         ProfileStatus `json:"status"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	StateUpdatedAt *time.Time    `json:"stateUpdatedAt,omitempty"` // Last storage-state upload
+	LastUsedAt     *time.Time    `json:"lastUsedAt,omitempty"`     // Last signed-in join
+	ExpiredAt      *time.Time    `json:"expiredAt,omitempty"`
+	LastError      string        `json:"lastError,omitempty"`
+}
+
+// ProfileRequest describes a profile to create.
+type ProfileRequest struct {
+	Name     string
+	Platform Platform
+}
+
+func (r ProfileRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	switch r.Platform {
+	case PlatformTeams, PlatformMeet:
+	default:
+		return fmt.Errorf("%w: profiles are supported for teams and meet, not %q", ErrInvalidRequest, r.Platform)
+	}
+	return nil
+}
+
+// StorageState is a browser login as cookies plus per-origin local storage,
+// in the format Playwright's storageState() writes.
+type StorageState struct {
+	Cookies []StorageCookie `json:"cookies"`
+	Origins []StorageOrigin `json:"origins"`
+}
+
+type StorageCookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires"` // Unix seconds; -1 for a session cookie
+	HTTPOnly bool    `json:"httpOnly"`
+	Secure   bool    `json:"secure"`
+	SameSite string  `json:"sameSite,omitempty"` // Strict, Lax or None
+}
+
+type StorageOrigin struct {
+	Origin       string        `json:"origin"`
+	LocalStorage []StorageItem `json:"localStorage"`
+}
+
+type StorageItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (s StorageState) Validate() error {
+	if len(s.Cookies) == 0 && len(s.Origins) == 0 {
+		return fmt.Errorf("%w: storage state has no cookies or origins", ErrInvalidRequest)
+	}
+	for _, c := range s.Cookies {
+		if c.Name == "" || c.Domain == "" {
+			return fmt.Errorf("%w: every cookie needs a name and domain", ErrInvalidRequest)
+		}
+		switch c.SameSite {
+		case "", "Strict", "Lax", "None":
+		default:
+			return fmt.Errorf("%w: cookie %s has unknown sameSite %q", ErrInvalidRequest, c.Name, c.SameSite)
+		}
+	}
+	for _, o := range s.Origins {
+		if !strings.HasPrefix(o.Origin, "https://") && !strings.HasPrefix(o.Origin, "http://") {
+			return fmt.Errorf("%w: origin %q must be an http(s) origin", ErrInvalidRequest, o.Origin)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+// StartRequest describes a recording to start.
+type StartRequest struct {
+	MeetingURL      string
+	ParticipantName string
+	CallbackURLs    []string // Webhook targets for this session, in addition to global ones
+	SeriesID        string   // Recurring meeting this recording belongs to, if any
+	// LobbyTimeoutMinutes bounds the wait for a host to admit the bot;
+	// defaults to DefaultLobbyTimeoutMinutes
+	LobbyTimeoutMinutes int
+	StopPolicy          StopPolicy
+	// Locale is the language the meeting client shows, e.g. "de" or "fr-CA";
+	// empty uses the bot's default
+	Locale string
+	// ProfileID picks a signed-in bot identity; empty joins as a guest
+	ProfileID string
+}
+
+const DefaultLobbyTimeoutMinutes = 10
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+func (r StartRequest) Validate() error {
+	if r.LobbyTimeoutMinutes < 0 {
+		return fmt.Errorf("%w: lobbyTimeoutMinutes must not be negative", ErrInvalidRequest)
+	}
+	if err := r.StopPolicy.Validate(); err != nil {
+		return err
+	}
+	if r.Locale != "" && !localePattern.MatchString(r.Locale) {
+		return fmt.Errorf("%w: locale %q is not a language tag", ErrInvalidRequest, r.Locale)
+	}
+	for _, raw := range r.CallbackURLs {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			return fmt.Errorf("%w: callback url %q must be an absolute http(s) URL", ErrInvalidRequest, raw)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrScheduleFinished = errors.New("schedule already finished")
+)
+
+type ScheduleStatus string
+
+const (
+	ScheduleScheduled ScheduleStatus = "scheduled"
+	ScheduleStarted   ScheduleStatus = "started"   // Recording in progress
+	ScheduleCompleted ScheduleStatus = "completed" // Recording ended
+	ScheduleCancelled ScheduleStatus = "cancelled"
+	ScheduleMissed    ScheduleStatus = "missed" // The service was down for the whole window
+	ScheduleFailed    ScheduleStatus = "failed" // StartRecording returned an error
+)
+
+// IsPending reports whether the scheduler still has work to do for the schedule.
+func (s ScheduleStatus) IsPending() bool {
+	return s == ScheduleScheduled || s == ScheduleStarted
+}
+
+const (
+	DefaultJoinLeadMinutes    = 2
+	DefaultMaxDurationMinutes = 120
+)
+
+// ScheduleRequest describes a recording to start at a future time.
+type ScheduleRequest struct {
+	MeetingURL          string
+	ParticipantName     string
+	CallbackURLs        []string
+	StartTime           string // RFC 3339, or a local wall time (2006-01-02T15:04[:05]) in TimeZone
+	TimeZone            string // IANA name; defaults to UTC
+	JoinLeadMinutes     int    // How early the bot joins; defaults to DefaultJoinLeadMinutes
+	MaxDurationMinutes  int    // Recording is stopped this long after the start time
+	LobbyTimeoutMinutes int    // Passed on to StartRequest
+	StopPolicy          StopPolicy
+	Locale              string // Passed on to StartRequest
+	ProfileID           string // Passed on to StartRequest
+	Title               string
+	SeriesID            string // Calendar UID shared by every occurrence of a recurring meeting
+	CalendarID          string // Subscription that created the schedule, if any
+}
+
+// Schedule is a persisted future recording.
+type Schedule struct {
+	ID                  string         `json:"id"`
+	Title               string         `json:"title,omitempty"`
+	MeetingURL          string         `json:"meetingUrl"`
+	ParticipantName     string         `json:"participantName"`
+	CallbackURLs        []string       `json:"callbackUrls,omitempty"`
+	StartAt             time.Time      `json:"startAt"` // Meeting start
+	TimeZone            string         `json:"timeZone"`
+	JoinLeadMinutes     int            `json:"joinLeadMinutes"`
+	MaxDurationMinutes  int            `json:"maxDurationMinutes"`
+	LobbyTimeoutMinutes int            `json:"lobbyTimeoutMinutes,omitempty"`
+	StopPolicy          StopPolicy     `json:"stopPolicy"`
+	Locale              string         `json:"locale,omitempty"`
+	ProfileID           string         `json:"profileId,omitempty"`
+	JoinAt              time.Time      `json:"joinAt"` // StartAt minus the join lead
+	Status              ScheduleStatus `json:"status"`
+	SessionID           string         `json:"sessionId,omitempty"`
+	SeriesID            string         `json:"seriesId,omitempty"`
+	CalendarID          string         `json:"calendarId,omitempty"`
+	Error               string         `json:"error,omitempty"`
+	CreatedAt           time.Time      `json:"createdAt"`
+}
+
+// Deadline is when the recording is stopped, counted from the meeting start.
+func (s *Schedule) Deadline() time.Time {
+	return s.StartAt.Add(time.Duration(s.MaxDurationMinutes) * time.Minute)
+}
+
+// StartRequest is what the scheduler passes to the recording service.
+func (s *Schedule) StartRequest() StartRequest {
+	return StartRequest{
+		MeetingURL:          s.MeetingURL,
+		ParticipantName:     s.ParticipantName,
+		CallbackURLs:        s.CallbackURLs,
+		SeriesID:            s.SeriesID,
+		LobbyTimeoutMinutes: s.LobbyTimeoutMinutes,
+		StopPolicy:          s.StopPolicy,
+		Locale:              s.Locale,
+		ProfileID:           s.ProfileID,
+	}
+}
+
+// Build validates the request and resolves the start time to an instant.
+func (r ScheduleRequest) Build(id string, now time.Time) (*Schedule, error) {
+	if r.MeetingURL == "" {
+		return nil, fmt.Errorf("%w: meetingUrl is required", ErrInvalidRequest)
+	}
+	if err := (StartRequest{CallbackURLs: r.CallbackURLs, LobbyTimeoutMinutes: r.LobbyTimeoutMinutes, StopPolicy: r.StopPolicy, Locale: r.Locale}).Validate(); err != nil {
+		return nil, err
+	}
+
+	tz := r.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidRequest, tz)
+	}
+	startAt, err := parseScheduleTime(r.StartTime, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	lead, maxDuration := r.JoinLeadMinutes, r.MaxDurationMinutes
+	if lead == 0 {
+		lead = DefaultJoinLeadMinutes
+	}
+	if maxDuration == 0 {
+		maxDuration = DefaultMaxDurationMinutes
+	}
+	if lead < 0 || maxDuration < 0 {
+		return nil, fmt.Errorf("%w: joinLeadMinutes and maxDurationMinutes must not be negative", ErrInvalidRequest)
+	}
+
+	schedule := &Schedule{
+		ID:                  id,
+		Title:               r.Title,
+		MeetingURL:          r.MeetingURL,
+		ParticipantName:     r.ParticipantName,
+		CallbackURLs:        r.CallbackURLs,
+		StartAt:             startAt,
+		TimeZone:            tz,
+		JoinLeadMinutes:     lead,
+		MaxDurationMinutes:  maxDuration,
+		LobbyTimeoutMinutes: r.LobbyTimeoutMinutes,
+		StopPolicy:          r.StopPolicy,
+		Locale:              r.Locale,
+		ProfileID:           r.ProfileID,
+		JoinAt:              startAt.Add(-time.Duration(lead) * time.Minute),
+		Status:              ScheduleScheduled,
+		SeriesID:            r.SeriesID,
+		CalendarID:          r.CalendarID,
+		CreatedAt:           now,
+	}
+	if !schedule.Deadline().After(now) {
+		return nil, fmt.Errorf("%w: meeting window has already passed", ErrInvalidRequest)
+	}
+	return schedule, nil
+}
+
+func parseScheduleTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%w: startTime is required", ErrInvalidRequest)
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: startTime %q must be RFC 3339 or a local 2006-01-02T15:04 time", ErrInvalidRequest, value)
+}
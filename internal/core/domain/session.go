@@ -1,30 +1,77 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
+var ErrSessionNotFound = errors.New("session not found")
+
 type SessionStatus string
 
 const (
 	StatusInitializing SessionStatus = "initializing"
 	StatusJoining      SessionStatus = "joining"
+	StatusInLobby      SessionStatus = "in_lobby" // Waiting for a host to let the bot in
+	StatusAdmitted     SessionStatus = "admitted" // In the meeting; the recorder is starting
 	StatusRecording    SessionStatus = "recording"
 	StatusStopping     SessionStatus = "stopping"
 	StatusStopped      SessionStatus = "stopped"
+	StatusCancelled    SessionStatus = "cancelled" // Stopped before recording began
+	StatusDenied       SessionStatus = "denied"    // A host refused to let the bot in
 	StatusError        SessionStatus = "error"
+	StatusInterrupted  SessionStatus = "interrupted" // Process exited while the session was still active
 )
 
+// IsActive reports whether a session in this status still owns a browser or recorder.
+func (s SessionStatus) IsActive() bool {
+	switch s {
+	case StatusInitializing, StatusJoining, StatusInLobby, StatusAdmitted, StatusRecording, StatusStopping:
+		return true
+	}
+	return false
+}
+
 type MeetingSession struct {
-	ID              string        `json:"sessionId"`
-	MeetingURL      string        `json:"meetingUrl"`
-	ParticipantName string        `json:"participantName"`
-	Status          SessionStatus `json:"status"`
-	StartTime       *time.Time    `json:"startTime,omitempty"`
-	EndTime         *time.Time    `json:"endTime,omitempty"`
-	FilePath        string        `json:"filePath,omitempty"`
-	Duration        string        `json:"duration,omitempty"` // Formatted duration
-	Error           string        `json:"error,omitempty"`
+	ID                  string        `json:"sessionId"`
+	MeetingURL          string        `json:"meetingUrl"`
+	Platform            Platform      `json:"platform"`
+	ParticipantName     string        `json:"participantName"`
+	CallbackURLs        []string      `json:"callbackUrls,omitempty"`
+	SeriesID            string        `json:"seriesId,omitempty"` // Shared by recordings of one recurring meeting
+	LobbyTimeoutMinutes int           `json:"lobbyTimeoutMinutes,omitempty"`
+	StopPolicy          StopPolicy    `json:"stopPolicy"`
+	Locale              string        `json:"locale,omitempty"`    // Client UI language; empty is the bot's default
+	ProfileID           string        `json:"profileId,omitempty"` // Signed-in identity; empty joined as a guest
+	Status              SessionStatus `json:"status"`
+	CreatedAt           time.Time     `json:"createdAt"`
+	StartTime           *time.Time    `json:"startTime,omitempty"`
+	EndTime             *time.Time    `json:"endTime,omitempty"`
+	FilePath            string        `json:"filePath,omitempty"` // Same as Artifacts[ArtifactRecording]
+	Artifacts           Artifacts     `json:"artifacts,omitempty"`
+	Duration            string        `json:"duration,omitempty"` // Formatted duration
+	Error               string        `json:"error,omitempty"`
+	StopReason          StopReason    `json:"stopReason,omitempty"`
+
+	TranscriptStatus ProcessingStatus    `json:"transcriptStatus,omitempty"`
+	TranscriptError  string              `json:"transcriptError,omitempty"`
+	TranscriptSource TranscriptSource    `json:"transcriptSource,omitempty"`
+	Transcript       []TranscriptSegment `json:"transcript,omitempty"`
+	Captions         []TranscriptSegment `json:"captions,omitempty"` // Live captions scraped during the meeting
+	Chat             []ChatMessage       `json:"chat,omitempty"`
+	Presence         []PresenceEvent     `json:"presence,omitempty"` // Roster join/leave timeline
+
+	MinutesStatus ProcessingStatus `json:"minutesStatus,omitempty"`
+	MinutesError  string           `json:"minutesError,omitempty"`
+	Minutes       *Minutes         `json:"minutes,omitempty"`
+}
+
+// LobbyWait is how long the bot waits in the lobby before giving up.
+func (s *MeetingSession) LobbyWait() time.Duration {
+	if s.LobbyTimeoutMinutes <= 0 {
+		return DefaultLobbyTimeoutMinutes * time.Minute
+	}
+	return time.Duration(s.LobbyTimeoutMinutes) * time.Minute
 }
 
 func (s *MeetingSession) CalculateDuration() {
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSessionActive = errors.New("session is still active")
+	ErrInvalidFilter = errors.New("invalid session filter")
+)
+
+type SessionSortField string
+
+const (
+	SortByCreatedAt SessionSortField = "createdAt"
+	SortByStartTime SessionSortField = "startTime"
+	SortByEndTime   SessionSortField = "endTime"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// SessionFilter selects sessions for listing. Zero values match everything.
+type SessionFilter struct {
+	Status          SessionStatus
+	Platform        Platform
+	ParticipantName string // Case-insensitive substring match
+	SeriesID        string
+	CreatedFrom     *time.Time // Inclusive
+	CreatedTo       *time.Time // Exclusive
+
+	SortBy     SessionSortField
+	Descending bool
+	Limit      int
+	Cursor     string // Opaque, from a previous SessionPage.NextCursor
+}
+
+type SessionPage struct {
+	Sessions   []*MeetingSession `json:"sessions"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+// Normalize fills defaults and rejects values the query cannot honour.
+func (f *SessionFilter) Normalize() error {
+	switch f.SortBy {
+	case "":
+		f.SortBy = SortByCreatedAt
+	case SortByCreatedAt, SortByStartTime, SortByEndTime:
+	default:
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidFilter, f.SortBy)
+	}
+
+	if f.Limit <= 0 {
+		f.Limit = DefaultPageSize
+	}
+	if f.Limit > MaxPageSize {
+		f.Limit = MaxPageSize
+	}
+	return nil
+}
+
+func (f *SessionFilter) Matches(s *MeetingSession) bool {
+	if f.Status != "" && s.Status != f.Status {
+		return false
+	}
+	if f.Platform != "" && s.Platform != f.Platform {
+		return false
+	}
+	if f.ParticipantName != "" && !strings.Contains(strings.ToLower(s.ParticipantName), strings.ToLower(f.ParticipantName)) {
+		return false
+	}
+	if f.SeriesID != "" && s.SeriesID != f.SeriesID {
+		return false
+	}
+	if f.CreatedFrom != nil && s.CreatedAt.Before(*f.CreatedFrom) {
+		return false
+	}
+	if f.CreatedTo != nil && !s.CreatedAt.Before(*f.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// SortKey returns the time a session is ordered by. Unset times are zero and
+// sort before all others.
+func (f *SessionFilter) SortKey(s *MeetingSession) time.Time {
+	switch f.SortBy {
+	case SortByStartTime:
+		if s.StartTime != nil {
+			return *s.StartTime
+		}
+	case SortByEndTime:
+		if s.EndTime != nil {
+			return *s.EndTime
+		}
+	default:
+		return s.CreatedAt
+	}
+	return time.Time{}
+}
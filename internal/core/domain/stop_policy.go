@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// StopPolicy ends a recording on its own when the meeting is effectively
+// over. A zero field disables that check.
+type StopPolicy struct {
+	AloneMinutes       int `json:"aloneMinutes,omitempty"`       // Nobody but the bot on the roster
+	SilenceMinutes     int `json:"silenceMinutes,omitempty"`     // No audio above the noise floor
+	MaxDurationMinutes int `json:"maxDurationMinutes,omitempty"` // Counted from the recording start
+}
+
+func (p StopPolicy) Validate() error {
+	if p.AloneMinutes < 0 || p.SilenceMinutes < 0 || p.MaxDurationMinutes < 0 {
+		return fmt.Errorf("%w: stop policy minutes must not be negative", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// Triggered returns the reason the first exceeded limit calls for a stop,
+// or "" if none does. A zero alone or silent duration means the condition
+// does not currently hold.
+func (p StopPolicy) Triggered(recording, alone, silent time.Duration) StopReason {
+	exceeds := func(d time.Duration, minutes int) bool {
+		return minutes > 0 && d > 0 && d >= time.Duration(minutes)*time.Minute
+	}
+	switch {
+	case exceeds(recording, p.MaxDurationMinutes):
+		return StopMaxDuration
+	case exceeds(alone, p.AloneMinutes):
+		return StopAlone
+	case exceeds(silent, p.SilenceMinutes):
+		return StopSilence
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrTranscriptNotReady = errors.New("transcript not ready")
+
+// ProcessingStatus tracks a post-recording stage such as transcription.
+type ProcessingStatus string
+
+const (
+	ProcessingPending ProcessingStatus = "pending"
+	ProcessingRunning ProcessingStatus = "running"
+	ProcessingDone    ProcessingStatus = "done"
+	ProcessingFailed  ProcessingStatus = "failed"
+	ProcessingSkipped ProcessingStatus = "skipped" // Nothing to process, e.g. no audio captured
+)
+
+// TranscriptSource records where a session's transcript came from.
+type TranscriptSource string
+
+const (
+	TranscriptFromSTT      TranscriptSource = "stt"      // Transcriber run over the recorded audio
+	TranscriptFromCaptions TranscriptSource = "captions" // Platform live captions, with speaker names
+)
+
+// TranscriptSegment is one utterance. Start and End are offsets from the
+// beginning of the recording.
+type TranscriptSegment struct {
+	Start   time.Duration
+	End     time.Duration
+	Speaker string
+	Text    string
+}
+
+// transcriptSegmentJSON carries offsets as fractional seconds, the unit
+// subtitle tooling and STT engines use.
+type transcriptSegmentJSON struct {
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Speaker string  `json:"speaker,omitempty"`
+	Text    string  `json:"text"`
+}
+
+func (s TranscriptSegment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transcriptSegmentJSON{
+		Start:   s.Start.Seconds(),
+		End:     s.End.Seconds(),
+		Speaker: s.Speaker,
+		Text:    s.Text,
+	})
+}
+
+func (s *TranscriptSegment) UnmarshalJSON(data []byte) error {
+	var raw transcriptSegmentJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Start = secondsToDuration(raw.Start)
+	s.End = secondsToDuration(raw.End)
+	s.Speaker = raw.Speaker
+	s.Text = raw.Text
+	return nil
+}
+
+// secondsToDuration rounds to whole milliseconds so offsets survive a JSON round trip exactly.
+func secondsToDuration(sec float64) time.Duration {
+	return time.Duration(math.Round(sec*1000)) * time.Millisecond
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookRecordingStarted     WebhookEventType = "recording.started"
+	WebhookRecordingStopped     WebhookEventType = "recording.stopped"
+	WebhookRecordingFailed      WebhookEventType = "recording.failed"
+	WebhookRecordingAutoStopped WebhookEventType = "recording.auto_stopped"
+	WebhookRecordingCancelled   WebhookEventType = "recording.cancelled"
+	WebhookRecordingDenied      WebhookEventType = "recording.denied"
+)
+
+// WebhookPayload is the JSON body POSTed to callback URLs.
+type WebhookPayload struct {
+	ID        string           `json:"id"` // Stable across retries, for receiver-side dedupe
+	Type      WebhookEventType `json:"type"`
+	Time      time.Time        `json:"time"`
+	SessionID string           `json:"sessionId"`
+	Reason    string           `json:"reason,omitempty"`
+	Session   *MeetingSession  `json:"session,omitempty"`
+}
+
+// WebhookDelivery is one payload bound for one URL, kept in the outbox until
+// it is acknowledged with a 2xx or runs out of attempts.
+type WebhookDelivery struct {
+	ID          string           `json:"id"`
+	URL         string           `json:"url"`
+	EventType   WebhookEventType `json:"eventType"`
+	Payload     json.RawMessage  `json:"payload"`
+	Attempts    int              `json:"attempts"`
+	NextAttempt time.Time        `json:"nextAttempt"`
+	LastError   string           `json:"lastError,omitempty"`
+	Dead        bool             `json:"dead,omitempty"` // Gave up; kept for auditing
+	CreatedAt   time.Time        `json:"createdAt"`
+}
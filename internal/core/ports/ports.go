@@ -4,26 +4,183 @@ import (
 	"context"
 	"go-meeting-recorder/internal/core/domain"
 	"io"
+	"time"
 )
 
 // Primary Port (Driving) - implemented by Service
 type RecordingService interface {
-	StartRecording(ctx context.Context, meetingUrl, participantName string) (*domain.MeetingSession, error)
+	StartRecording(ctx context.Context, req domain.StartRequest) (*domain.MeetingSession, error)
 	StopRecording(ctx context.Context, sessionId string) (*domain.MeetingSession, error)
 	GetSessionPlatform(ctx context.Context, sessionId string) (*domain.MeetingSession, error)
+	ListSessions(ctx context.Context, filter domain.SessionFilter) (*domain.SessionPage, error)
+	// GetArtifactPath returns the on-disk path of one of the session's files
+	GetArtifactPath(ctx context.Context, sessionId string, kind domain.ArtifactKind) (string, error)
+	// DeleteSession removes a finished session and its recording artifacts
+	DeleteSession(ctx context.Context, sessionId string) error
+	// SubscribeEvents streams events for one session, or every session if sessionId is empty.
+	// The channel is closed when ctx is done.
+	SubscribeEvents(ctx context.Context, sessionId string) (<-chan domain.Event, error)
+	// Shutdown stops every live session, finalizing recordings in progress
+	Shutdown(ctx context.Context) error
+}
+
+// Primary Port (Driving) - recordings booked ahead of time
+type ScheduleService interface {
+	CreateSchedule(ctx context.Context, req domain.ScheduleRequest) (*domain.Schedule, error)
+	GetSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error)
+	ListSchedules(ctx context.Context) ([]*domain.Schedule, error)
+	// CancelSchedule drops a pending schedule, stopping its recording if it already started
+	CancelSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error)
+}
+
+// Primary Port (Driving) - turns iCalendar invites into schedules
+type CalendarService interface {
+	// ImportCalendar schedules every upcoming occurrence in an uploaded .ics document
+	ImportCalendar(ctx context.Context, data []byte, opts domain.CalendarOptions) (*domain.CalendarImport, error)
+	Subscribe(ctx context.Context, url string, opts domain.CalendarOptions) (*domain.CalendarSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*domain.CalendarSubscription, error)
+	// Unsubscribe stops polling the feed and cancels schedules it created that have not started
+	Unsubscribe(ctx context.Context, calendarId string) error
+}
+
+// Primary Port (Driving) - signed-in identities the bot can join as
+type ProfileService interface {
+	CreateProfile(ctx context.Context, req domain.ProfileRequest) (*domain.BotProfile, error)
+	GetProfile(ctx context.Context, profileId string) (*domain.BotProfile, error)
+	ListProfiles(ctx context.Context) ([]*domain.BotProfile, error)
+	// DeleteProfile removes the profile and its stored login; a profile in use cannot be deleted
+	DeleteProfile(ctx context.Context, profileId string) error
+	// UpdateStorageState replaces the profile's login with an exported browser storage state
+	UpdateStorageState(ctx context.Context, profileId string, state domain.StorageState) (*domain.BotProfile, error)
+}
+
+// MeetingObserver receives what an automator notices while the bot is in a meeting.
+// Implemented by the service; calls may arrive from any goroutine.
+type MeetingObserver interface {
+	// MeetingEnded is called when the bot leaves the meeting without a stop
+	// request: the meeting ended, the bot was removed or the browser died
+	MeetingEnded(sessionId string, reason domain.StopReason)
+	// LobbyEntered is called when the bot is waiting for a host to admit it
+	LobbyEntered(sessionId string)
+	// ArtifactCaptured reports a file the automator wrote for the session
+	ArtifactCaptured(sessionId string, kind domain.ArtifactKind, path string)
+	// CaptionReceived delivers one finalized live-caption line
+	CaptionReceived(sessionId string, caption domain.Caption)
+	// ChatMessageReceived delivers one chat message, in the order they were posted
+	ChatMessageReceived(sessionId string, message domain.ChatMessage)
+	// RosterSampled reports everyone listed in the meeting roster at the given time
+	RosterSampled(sessionId string, participants []string, at time.Time)
 }
 
 // Secondary Port (Driven) - implemented by Adapters
 type BrowserAutomator interface {
+	// JoinMeeting returns once the bot is in the meeting. A refused join wraps
+	// domain.ErrAdmissionDenied; an unanswered one wraps domain.ErrLobbyTimeout.
+	// A session with a profile whose login no longer works wraps
+	// domain.ErrProfileSignedOut.
 	JoinMeeting(ctx context.Context, session *domain.MeetingSession) error
 	StopMeeting(ctx context.Context, sessionId string) error
 	GetSnapshot(ctx context.Context, sessionId string) ([]byte, error)
-	// GetMeetingStreams returns streams for audio and video
-	GetMeetingStreams(ctx context.Context, sessionId string) (videoStream io.Reader, audioStream io.Reader, err error)
+	// GetMeetingStreams returns streams for audio and video.
+	// Video frames arrive with capture timestamps; the channel is closed when capture ends.
+	// The audio stream is raw s16le PCM, 48kHz stereo, or nil if the session has no audio.
+	GetMeetingStreams(ctx context.Context, sessionId string) (videoFrames <-chan domain.VideoFrame, audioStream io.Reader, err error)
+	SetObserver(observer MeetingObserver)
 }
 
 // Secondary Port (Driven)
 type MediaRecorder interface {
-	Start(ctx context.Context, sessionId string, videoStream io.Reader, audioStream io.Reader) error
-	Stop(ctx context.Context, sessionId string) (string, error)
+	Start(ctx context.Context, sessionId string, videoFrames <-chan domain.VideoFrame, audioStream io.Reader) error
+	// Stop finalizes the recording and returns the files it produced
+	Stop(ctx context.Context, sessionId string) (domain.Artifacts, error)
+	// DeleteArtifacts removes every file recorded for the session
+	DeleteArtifacts(ctx context.Context, sessionId string) error
+}
+
+// Secondary Port (Driven) - persists sessions across restarts
+type SessionRepository interface {
+	Save(ctx context.Context, session *domain.MeetingSession) error
+	// Get returns domain.ErrSessionNotFound if no session has the given id
+	Get(ctx context.Context, sessionId string) (*domain.MeetingSession, error)
+	List(ctx context.Context) ([]*domain.MeetingSession, error)
+	Delete(ctx context.Context, sessionId string) error
+}
+
+// Secondary Port (Driven) - persists schedules across restarts
+type ScheduleRepository interface {
+	Save(ctx context.Context, schedule *domain.Schedule) error
+	Get(ctx context.Context, scheduleId string) (*domain.Schedule, error)
+	// List returns all schedules ordered by start time
+	List(ctx context.Context) ([]*domain.Schedule, error)
+}
+
+// Secondary Port (Driven) - persists polled calendar feeds
+type CalendarRepository interface {
+	Save(ctx context.Context, sub *domain.CalendarSubscription) error
+	Get(ctx context.Context, calendarId string) (*domain.CalendarSubscription, error)
+	List(ctx context.Context) ([]*domain.CalendarSubscription, error)
+	Delete(ctx context.Context, calendarId string) error
+}
+
+// Secondary Port (Driven) - persists bot profiles
+type ProfileRepository interface {
+	Save(ctx context.Context, profile *domain.BotProfile) error
+	// Get returns domain.ErrProfileNotFound if no profile has the given id
+	Get(ctx context.Context, profileId string) (*domain.BotProfile, error)
+	List(ctx context.Context) ([]*domain.BotProfile, error)
+	Delete(ctx context.Context, profileId string) error
+}
+
+// Secondary Port (Driven) - the browser side of a bot profile. The automator
+// joins with what is stored here when a session names the profile.
+type IdentityStore interface {
+	// SaveStorageState stores a login for the automator to load on the profile's next join
+	SaveStorageState(ctx context.Context, profileId string, state domain.StorageState) error
+	// DeleteIdentity removes the profile's stored login and browser data
+	DeleteIdentity(ctx context.Context, profileId string) error
+}
+
+// Secondary Port (Driven) - reads iCalendar documents
+type CalendarReader interface {
+	// Fetch downloads an ICS feed
+	Fetch(ctx context.Context, url string) ([]byte, error)
+	// Expand returns the occurrences starting in [from, to), with recurrences
+	// expanded, cancelled or excluded instances removed and malformed events skipped
+	Expand(data []byte, from, to time.Time) ([]domain.CalendarEvent, error)
+}
+
+// Secondary Port (Driven) - time source, swapped for a fake in tests
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// Secondary Port (Driven) - durable queue of pending webhook deliveries
+type WebhookOutbox interface {
+	Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// Due returns live deliveries whose NextAttempt is not after now, oldest first
+	Due(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+	Delete(ctx context.Context, deliveryId string) error
+	// PruneDead deletes dead deliveries created before cutoff and returns how many
+	PruneDead(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// Secondary Port (Driven) - performs one signed webhook POST
+type WebhookSender interface {
+	// Send returns an error unless the receiver answered 2xx
+	Send(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
+
+// Secondary Port (Driven) - speech-to-text over a finished recording
+type Transcriber interface {
+	// Transcribe returns segments ordered by start offset
+	Transcribe(ctx context.Context, audioPath string) ([]domain.TranscriptSegment, error)
+}
+
+// Secondary Port (Driven) - turns a transcript into meeting minutes
+type Summarizer interface {
+	// Name identifies the implementation in Minutes.GeneratedBy
+	Name() string
+	Summarize(ctx context.Context, input domain.MinutesInput) (*domain.Minutes, error)
 }
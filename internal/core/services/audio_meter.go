@@ -0,0 +1,65 @@
+package services
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// silenceThreshold is the RMS level, in s16 sample units, below which a chunk
+// of audio counts as silence. It sits around -45 dBFS, above comfort noise.
+const silenceThreshold = 180
+
+// audioMeter passes the recorder's PCM stream through unchanged and remembers
+// when it last carried sound. The stream is s16le, so samples are byte pairs.
+type audioMeter struct {
+	r         io.Reader
+	lastSound atomic.Int64 // Unix nanoseconds
+	carry     byte         // Low byte of a sample split across reads
+	hasCarry  bool
+}
+
+func newAudioMeter(r io.Reader) *audioMeter {
+	m := &audioMeter{r: r}
+	m.lastSound.Store(time.Now().UnixNano())
+	return m
+}
+
+func (m *audioMeter) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	if n > 0 {
+		m.measure(p[:n])
+	}
+	return n, err
+}
+
+func (m *audioMeter) measure(b []byte) {
+	var sum float64
+	samples := 0
+	add := func(lo, hi byte) {
+		sample := float64(int16(uint16(lo) | uint16(hi)<<8))
+		sum += sample * sample
+		samples++
+	}
+
+	if m.hasCarry && len(b) > 0 {
+		add(m.carry, b[0])
+		b = b[1:]
+		m.hasCarry = false
+	}
+	for ; len(b) >= 2; b = b[2:] {
+		add(b[0], b[1])
+	}
+	if len(b) == 1 {
+		m.carry, m.hasCarry = b[0], true
+	}
+
+	if samples > 0 && sum/float64(samples) > silenceThreshold*silenceThreshold {
+		m.lastSound.Store(time.Now().UnixNano())
+	}
+}
+
+// SilentFor is how long the stream has carried nothing but silence.
+func (m *audioMeter) SilentFor(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, m.lastSound.Load()))
+}
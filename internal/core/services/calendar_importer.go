@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// calendarOverrun is added to the invite's length to get the schedule's maximum duration.
+	calendarOverrun = 15 * time.Minute
+	// calendarLookback lets an import pick up meetings that are already in progress.
+	calendarLookback = 6 * time.Hour
+)
+
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"'\[\]]+`)
+
+// CalendarImporter turns iCalendar invites into schedules, either from an
+// uploaded file or from feeds it polls. Every occurrence of a recurring
+// meeting gets its own schedule, linked by the event UID as series ID.
+type CalendarImporter struct {
+	repo      ports.CalendarRepository
+	reader    ports.CalendarReader
+	scheduler *Scheduler
+	platforms *PlatformRegistry
+	clock     ports.Clock
+	interval  time.Duration // Between feed polls
+	horizon   time.Duration // How far ahead occurrences are scheduled
+	mu        sync.Mutex    // Serializes imports so duplicate detection holds
+}
+
+func NewCalendarImporter(repo ports.CalendarRepository, reader ports.CalendarReader, scheduler *Scheduler, platforms *PlatformRegistry, clock ports.Clock, interval, horizon time.Duration) *CalendarImporter {
+	return &CalendarImporter{
+		repo:      repo,
+		reader:    reader,
+		scheduler: scheduler,
+		platforms: platforms,
+		clock:     clock,
+		interval:  interval,
+		horizon:   horizon,
+	}
+}
+
+// Run polls every subscribed feed until ctx is done. Polling also extends
+// the horizon, so long-running series keep getting scheduled.
+func (c *CalendarImporter) Run(ctx context.Context) {
+	for {
+		c.syncAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(c.interval):
+		}
+	}
+}
+
+func (c *CalendarImporter) ImportCalendar(ctx context.Context, data []byte, opts domain.CalendarOptions) (*domain.CalendarImport, error) {
+	result, err := c.importEvents(ctx, data, opts, "")
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Calendar] Imported upload: %d scheduled, %d without a meeting link", len(result.Created), len(result.Skipped))
+	return result, nil
+}
+
+func (c *CalendarImporter) Subscribe(ctx context.Context, feedUrl string, opts domain.CalendarOptions) (*domain.CalendarSubscription, error) {
+	u, err := url.Parse(feedUrl)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http" && u.Scheme != "webcal") {
+		return nil, fmt.Errorf("%w: calendar url %q must be an absolute http(s) or webcal URL", domain.ErrInvalidRequest, feedUrl)
+	}
+	if err := (domain.StartRequest{CallbackURLs: opts.CallbackURLs}).Validate(); err != nil {
+		return nil, err
+	}
+
+	// Fetch once up front so a wrong URL is reported to the caller
+	data, err := c.reader.Fetch(ctx, feedUrl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not fetch calendar: %v", domain.ErrInvalidRequest, err)
+	}
+
+	sub := &domain.CalendarSubscription{
+		ID:              uuid.New().String(),
+		URL:             feedUrl,
+		ParticipantName: opts.ParticipantName,
+		CallbackURLs:    opts.CallbackURLs,
+		JoinLeadMinutes: opts.JoinLeadMinutes,
+		CreatedAt:       c.clock.Now(),
+	}
+	result, err := c.importEvents(ctx, data, sub.Options(), sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	syncedAt := c.clock.Now()
+	sub.LastSyncAt = &syncedAt
+	if err := c.repo.Save(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to save calendar: %w", err)
+	}
+	log.Printf("[Calendar] Subscribed calendar %s: %d scheduled", sub.ID, len(result.Created))
+	return sub, nil
+}
+
+func (c *CalendarImporter) ListSubscriptions(ctx context.Context) ([]*domain.CalendarSubscription, error) {
+	return c.repo.List(ctx)
+}
+
+func (c *CalendarImporter) Unsubscribe(ctx context.Context, calendarId string) error {
+	if err := c.repo.Delete(ctx, calendarId); err != nil {
+		return err
+	}
+
+	schedules, err := c.scheduler.ListSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if schedule.CalendarID == calendarId && schedule.Status == domain.ScheduleScheduled {
+			if _, err := c.scheduler.CancelSchedule(ctx, schedule.ID); err != nil {
+				log.Printf("[Calendar] Failed to cancel schedule %s: %v", schedule.ID, err)
+			}
+		}
+	}
+	log.Printf("[Calendar] Unsubscribed calendar %s", calendarId)
+	return nil
+}
+
+func (c *CalendarImporter) syncAll(ctx context.Context) {
+	subs, err := c.repo.List(ctx)
+	if err != nil {
+		log.Printf("[Calendar] Failed to load subscriptions: %v", err)
+		return
+	}
+	for _, sub := range subs {
+		data, err := c.reader.Fetch(ctx, sub.URL)
+		if err != nil {
+			log.Printf("[Calendar] Failed to fetch calendar %s: %v", sub.ID, err)
+			sub.LastError = err.Error()
+			if err := c.repo.Save(ctx, sub); err != nil {
+				log.Printf("[Calendar] Failed to save calendar %s: %v", sub.ID, err)
+			}
+			continue
+		}
+		c.sync(ctx, sub, data)
+	}
+}
+
+// sync imports the feed and records the outcome on the subscription.
+func (c *CalendarImporter) sync(ctx context.Context, sub *domain.CalendarSubscription, data []byte) {
+	result, err := c.importEvents(ctx, data, sub.Options(), sub.ID)
+	now := c.clock.Now()
+	sub.LastSyncAt = &now
+	sub.LastError = ""
+	if err != nil {
+		log.Printf("[Calendar] Failed to import calendar %s: %v", sub.ID, err)
+		sub.LastError = err.Error()
+	} else if len(result.Created) > 0 || len(result.Cancelled) > 0 {
+		log.Printf("[Calendar] Synced calendar %s: %d scheduled, %d cancelled", sub.ID, len(result.Created), len(result.Cancelled))
+	}
+	if err := c.repo.Save(ctx, sub); err != nil {
+		log.Printf("[Calendar] Failed to save calendar %s: %v", sub.ID, err)
+	}
+}
+
+// importEvents schedules every upcoming occurrence not scheduled before. For
+// feeds (calendarId set), occurrences that disappeared from the feed, e.g.
+// through a new EXDATE or a cancelled invite, have their schedules cancelled.
+func (c *CalendarImporter) importEvents(ctx context.Context, data []byte, opts domain.CalendarOptions, calendarId string) (*domain.CalendarImport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	from, until := now.Add(-calendarLookback), now.Add(c.horizon)
+	events, err := c.reader.Expand(data, from, until)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRequest, err)
+	}
+
+	schedules, err := c.scheduler.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(schedules))
+	for _, s := range schedules {
+		if s.SeriesID != "" {
+			existing[occurrenceKey(s.SeriesID, s.StartAt)] = true
+		}
+	}
+
+	result := &domain.CalendarImport{
+		Created:   []*domain.Schedule{},
+		Cancelled: []*domain.Schedule{},
+		Skipped:   []string{},
+	}
+	inFeed := make(map[string]bool, len(events))
+	for _, event := range events {
+		if !event.End.After(now) {
+			continue
+		}
+		key := occurrenceKey(event.UID, event.Start)
+		inFeed[key] = true
+		if existing[key] {
+			continue
+		}
+
+		link := c.meetingLink(event)
+		if link == "" {
+			result.Skipped = append(result.Skipped, event.Summary)
+			continue
+		}
+
+		length := event.End.Sub(event.Start) + calendarOverrun
+		schedule, err := c.scheduler.CreateSchedule(ctx, domain.ScheduleRequest{
+			MeetingURL:         link,
+			ParticipantName:    opts.ParticipantName,
+			CallbackURLs:       opts.CallbackURLs,
+			StartTime:          event.Start.Format(time.RFC3339),
+			TimeZone:           event.TimeZone,
+			JoinLeadMinutes:    opts.JoinLeadMinutes,
+			MaxDurationMinutes: int((length + time.Minute - 1) / time.Minute),
+			Title:              event.Summary,
+			SeriesID:           event.UID,
+			CalendarID:         calendarId,
+		})
+		if err != nil {
+			log.Printf("[Calendar] Skipping %q at %s: %v", event.Summary, event.Start.Format(time.RFC3339), err)
+			result.Skipped = append(result.Skipped, event.Summary)
+			continue
+		}
+		existing[key] = true
+		result.Created = append(result.Created, schedule)
+	}
+
+	if calendarId != "" {
+		for _, s := range schedules {
+			if s.CalendarID != calendarId || s.Status != domain.ScheduleScheduled ||
+				s.StartAt.Before(from) || !s.StartAt.Before(until) {
+				continue
+			}
+			if inFeed[occurrenceKey(s.SeriesID, s.StartAt)] {
+				continue
+			}
+			cancelled, err := c.scheduler.CancelSchedule(ctx, s.ID)
+			if err != nil {
+				log.Printf("[Calendar] Failed to cancel schedule %s: %v", s.ID, err)
+				continue
+			}
+			result.Cancelled = append(result.Cancelled, cancelled)
+		}
+	}
+	return result, nil
+}
+
+// meetingLink returns the first URL in the invite that a registered automator can join.
+func (c *CalendarImporter) meetingLink(event domain.CalendarEvent) string {
+	for _, text := range []string{event.URL, event.Location, event.Description} {
+		for _, candidate := range linkPattern.FindAllString(text, -1) {
+			candidate = strings.TrimRight(candidate, ".,;:)>")
+			if _, _, err := c.platforms.Resolve(candidate); err == nil {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+func occurrenceKey(seriesId string, start time.Time) string {
+	return seriesId + "|" + start.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// fakeCalendarReader serves a fixed list of occurrences, ignoring the
+// document, so tests can change the feed between syncs.
+type fakeCalendarReader struct {
+	mu     sync.Mutex
+	events []domain.CalendarEvent
+}
+
+var _ ports.CalendarReader = (*fakeCalendarReader)(nil)
+
+func (r *fakeCalendarReader) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil
+}
+
+func (r *fakeCalendarReader) Expand(data []byte, from, to time.Time) ([]domain.CalendarEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []domain.CalendarEvent
+	for _, e := range r.events {
+		if !e.Start.Before(from) && e.Start.Before(to) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeCalendarReader) setEvents(events ...domain.CalendarEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = events
+}
+
+func invite(uid, summary, url string, start time.Time) domain.CalendarEvent {
+	return domain.CalendarEvent{UID: uid, Summary: summary, URL: url, Start: start, End: start.Add(30 * time.Minute), TimeZone: "UTC"}
+}
+
+type importerFixture struct {
+	importer  *CalendarImporter
+	reader    *fakeCalendarReader
+	calendars ports.CalendarRepository
+	schedules ports.ScheduleRepository
+	clock     *fakeClock
+}
+
+func newImporterFixture(now time.Time) *importerFixture {
+	f := &importerFixture{
+		reader:    &fakeCalendarReader{},
+		calendars: memory.NewCalendarRepository(),
+		schedules: memory.NewScheduleRepository(),
+		clock:     newFakeClock(now),
+	}
+	platforms := NewPlatformRegistry()
+	platforms.Register(domain.PlatformMeet, &stuckAutomator{})
+	scheduler := NewScheduler(f.schedules, newFakeRecorder(), platforms, NewEventBus(), f.clock)
+	f.importer = NewCalendarImporter(f.calendars, f.reader, scheduler, platforms, f.clock, 15*time.Minute, 7*24*time.Hour)
+	return f
+}
+
+func TestCalendarImportSchedulesUpcomingMeetings(t *testing.T) {
+	ctx := context.Background()
+	f := newImporterFixture(meetingStart.Add(-time.Hour))
+	f.reader.setEvents(
+		invite("past", "Yesterday's sync", "https://meet.google.com/abc-defg-hij", meetingStart.Add(-24*time.Hour)),
+		invite("standup", "Standup", "https://meet.google.com/abc-defg-hij", meetingStart),
+		domain.CalendarEvent{
+			UID: "review", Summary: "Review", Description: "Join: https://meet.google.com/xyz-abcd-efg.",
+			Start: meetingStart.Add(24 * time.Hour), End: meetingStart.Add(25 * time.Hour), TimeZone: "UTC",
+		},
+		invite("lunch", "Lunch", "", meetingStart.Add(2*time.Hour)),
+		invite("far", "Next month", "https://meet.google.com/abc-defg-hij", meetingStart.AddDate(0, 1, 0)),
+	)
+
+	result, err := f.importer.ImportCalendar(ctx, nil, domain.CalendarOptions{ParticipantName: "Minutes Bot", JoinLeadMinutes: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("created %d schedules, want 2", len(result.Created))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "Lunch" {
+		t.Errorf("skipped %q, want the invite without a meeting link", result.Skipped)
+	}
+
+	standup, review := result.Created[0], result.Created[1]
+	if !standup.StartAt.Equal(meetingStart) || standup.SeriesID != "standup" || standup.Title != "Standup" {
+		t.Errorf("standup scheduled as %+v", standup)
+	}
+	if !standup.JoinAt.Equal(meetingStart.Add(-2 * time.Minute)) {
+		t.Errorf("standup joins at %s, want 2 minutes early", standup.JoinAt)
+	}
+	// The invite's 30 minutes plus the overrun
+	if standup.MaxDurationMinutes != 45 {
+		t.Errorf("standup may run %d minutes, want 45", standup.MaxDurationMinutes)
+	}
+	if review.MeetingURL != "https://meet.google.com/xyz-abcd-efg" {
+		t.Errorf("review link %q, want the one from the description without trailing punctuation", review.MeetingURL)
+	}
+
+	// Importing the same file again schedules nothing new
+	again, err := f.importer.ImportCalendar(ctx, nil, domain.CalendarOptions{ParticipantName: "Minutes Bot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Created) != 0 {
+		t.Errorf("re-import created %d duplicate schedules", len(again.Created))
+	}
+}
+
+func TestCalendarSyncCancelsRemovedOccurrences(t *testing.T) {
+	ctx := context.Background()
+	f := newImporterFixture(meetingStart.Add(-time.Hour))
+	link := "https://meet.google.com/abc-defg-hij"
+	monday := invite("daily", "Daily", link, meetingStart)
+	tuesday := invite("daily", "Daily", link, meetingStart.Add(24*time.Hour))
+	f.reader.setEvents(monday, tuesday)
+
+	sub, err := f.importer.Subscribe(ctx, "https://calendar.example.com/feed.ics", domain.CalendarOptions{ParticipantName: "Minutes Bot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedules, _ := f.schedules.List(ctx)
+	if len(schedules) != 2 {
+		t.Fatalf("subscribing scheduled %d occurrences, want 2", len(schedules))
+	}
+
+	// Tuesday gets an EXDATE and a new occurrence appears on Wednesday
+	wednesday := invite("daily", "Daily", link, meetingStart.Add(48*time.Hour))
+	f.reader.setEvents(monday, wednesday)
+	syncedAt := meetingStart.Add(-30 * time.Minute)
+	f.clock.Set(syncedAt)
+	f.importer.syncAll(ctx)
+
+	status := make(map[time.Time]domain.ScheduleStatus)
+	schedules, _ = f.schedules.List(ctx)
+	for _, s := range schedules {
+		if s.CalendarID != sub.ID {
+			t.Errorf("schedule %s belongs to calendar %q, want %q", s.ID, s.CalendarID, sub.ID)
+		}
+		status[s.StartAt.UTC()] = s.Status
+	}
+	want := map[time.Time]domain.ScheduleStatus{
+		monday.Start:    domain.ScheduleScheduled,
+		tuesday.Start:   domain.ScheduleCancelled,
+		wednesday.Start: domain.ScheduleScheduled,
+	}
+	for start, w := range want {
+		if status[start] != w {
+			t.Errorf("occurrence at %s is %q, want %q", start, status[start], w)
+		}
+	}
+
+	stored, err := f.calendars.Get(ctx, sub.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.LastSyncAt == nil || !stored.LastSyncAt.Equal(syncedAt) || stored.LastError != "" {
+		t.Errorf("subscription synced at %v with error %q, want %s and none", stored.LastSyncAt, stored.LastError, syncedAt)
+	}
+}
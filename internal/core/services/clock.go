@@ -0,0 +1,18 @@
+package services
+
+import (
+	"time"
+
+	"go-meeting-recorder/internal/core/ports"
+)
+
+type systemClock struct{}
+
+// NewSystemClock returns the wall clock.
+func NewSystemClock() ports.Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind
+// before events are dropped for it. Publishing never blocks the service.
+const subscriberBuffer = 64
+
+type subscriber struct {
+	sessionId string // Empty subscribes to every session
+	ch        chan domain.Event
+}
+
+// EventBus fans session events out to in-process subscribers. Subscribers
+// may miss events when they fall behind; consumers that must see every event,
+// like the webhook outbox, register a handler instead.
+type EventBus struct {
+	mu          sync.Mutex
+	nextID      uint64
+	subscribers map[*subscriber]struct{}
+	handlers    []func(domain.Event)
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Handle registers fn to run for every event in the publisher's goroutine,
+// before Publish returns. Publishers may hold locks, so fn must not block long.
+func (b *EventBus) Handle(fn func(domain.Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, fn)
+}
+
+// Publish stamps the event with an ID and time, delivers it to matching
+// subscribers and runs the handlers.
+func (b *EventBus) Publish(event domain.Event) {
+	b.mu.Lock()
+	event = b.deliverLocked(event)
+	handlers := b.handlers
+	b.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn(event)
+	}
+}
+
+func (b *EventBus) deliverLocked(event domain.Event) domain.Event {
+	b.nextID++
+	event.ID = b.nextID
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	for sub := range b.subscribers {
+		if sub.sessionId != "" && sub.sessionId != event.SessionID {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			log.Printf("[Events] Subscriber is behind, dropping event %d (%s)", event.ID, event.Type)
+		}
+	}
+	return event
+}
+
+// Subscribe returns a channel of events for one session, or all sessions if
+// sessionId is empty. Call cancel to unsubscribe; the channel is then closed.
+func (b *EventBus) Subscribe(sessionId string) (<-chan domain.Event, func()) {
+	sub := &subscriber{
+		sessionId: sessionId,
+		ch:        make(chan domain.Event, subscriberBuffer),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, sub)
+			b.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+	return sub.ch, cancel
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// PlatformRegistry detects the meeting platform from a URL and hands out
+// the automator registered for it.
+type PlatformRegistry struct {
+	automators   map[domain.Platform]ports.BrowserAutomator
+	jitsiDomains []string
+}
+
+// NewPlatformRegistry creates an empty registry. Jitsi is self-hosted, so its
+// domains must be listed explicitly (e.g. "meet.jit.si", "jitsi.example.com").
+func NewPlatformRegistry(jitsiDomains ...string) *PlatformRegistry {
+	domains := make([]string, 0, len(jitsiDomains))
+	for _, d := range jitsiDomains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return &PlatformRegistry{
+		automators:   make(map[domain.Platform]ports.BrowserAutomator),
+		jitsiDomains: domains,
+	}
+}
+
+func (r *PlatformRegistry) Register(platform domain.Platform, automator ports.BrowserAutomator) {
+	r.automators[platform] = automator
+}
+
+// Detect parses the meeting URL and identifies the platform by host.
+func (r *PlatformRegistry) Detect(meetingUrl string) (domain.Platform, error) {
+	u, err := url.Parse(strings.TrimSpace(meetingUrl))
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", &domain.UnsupportedMeetingError{URL: meetingUrl, Reason: "not an absolute http(s) URL"}
+	}
+	host := strings.ToLower(u.Hostname())
+
+	switch {
+	case host == "teams.live.com" || host == "teams.microsoft.com" || strings.HasSuffix(host, ".teams.microsoft.com"):
+		return domain.PlatformTeams, nil
+	case host == "meet.google.com":
+		return domain.PlatformMeet, nil
+	case host == "zoom.us" || strings.HasSuffix(host, ".zoom.us"):
+		return domain.PlatformZoom, nil
+	}
+
+	for _, d := range r.jitsiDomains {
+		if host == d {
+			return domain.PlatformJitsi, nil
+		}
+	}
+
+	return "", &domain.UnsupportedMeetingError{URL: meetingUrl, Reason: "unrecognised meeting host " + host}
+}
+
+// Resolve detects the platform and returns its automator.
+func (r *PlatformRegistry) Resolve(meetingUrl string) (domain.Platform, ports.BrowserAutomator, error) {
+	platform, err := r.Detect(meetingUrl)
+	if err != nil {
+		return "", nil, err
+	}
+
+	automator, ok := r.automators[platform]
+	if !ok {
+		return platform, nil, &domain.UnsupportedMeetingError{URL: meetingUrl, Platform: platform, Reason: "no automator registered for this platform"}
+	}
+	return platform, automator, nil
+}
+
+// Automator returns the automator for a platform already stored on a session.
+func (r *PlatformRegistry) Automator(platform domain.Platform) (ports.BrowserAutomator, bool) {
+	automator, ok := r.automators[platform]
+	return automator, ok
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// postProcessQueue bounds the backlog held in memory. Sessions that do not
+// fit stay marked pending in the repository and are resumed on next start.
+const postProcessQueue = 256
+
+// PostProcessor runs the post-recording stages (transcription, then minutes)
+// for sessions once they reach StatusStopped. Work is serialized on a single
+// worker since every stage is CPU heavy.
+type PostProcessor struct {
+	repo        ports.SessionRepository
+	events      *EventBus
+	transcriber ports.Transcriber
+	summarizer  ports.Summarizer // May be nil; fallback is used alone then
+	fallback    ports.Summarizer
+	queue       chan string
+}
+
+// NewPostProcessor wires the stages. If summarizer fails (e.g. the LLM
+// endpoint is down) fallback produces the minutes instead.
+func NewPostProcessor(repo ports.SessionRepository, events *EventBus, transcriber ports.Transcriber, summarizer, fallback ports.Summarizer) *PostProcessor {
+	p := &PostProcessor{
+		repo:        repo,
+		events:      events,
+		transcriber: transcriber,
+		summarizer:  summarizer,
+		fallback:    fallback,
+		queue:       make(chan string, postProcessQueue),
+	}
+	// Handled synchronously, so the session is marked pending as part of
+	// stopping it and a busy bus cannot drop the work
+	events.Handle(func(event domain.Event) {
+		if event.Type == domain.EventStatusChanged && event.Status == domain.StatusStopped {
+			p.schedule(context.Background(), event.SessionID)
+		}
+	})
+	return p
+}
+
+// Run resumes unfinished work from a previous run, then processes sessions
+// as they stop until ctx is done.
+func (p *PostProcessor) Run(ctx context.Context) {
+	p.resume(ctx)
+	p.worker(ctx)
+}
+
+func (p *PostProcessor) resume(ctx context.Context) {
+	sessions, err := p.repo.List(ctx)
+	if err != nil {
+		log.Printf("[PostProcess] Failed to load sessions: %v", err)
+		return
+	}
+	for _, session := range sessions {
+		if unfinished(session.TranscriptStatus) || unfinished(session.MinutesStatus) {
+			p.enqueue(session.ID)
+		}
+	}
+}
+
+// schedule marks the session pending before queueing so the work survives a restart.
+func (p *PostProcessor) schedule(ctx context.Context, sessionId string) {
+	err := p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+		s.TranscriptStatus = domain.ProcessingPending
+		s.MinutesStatus = domain.ProcessingPending
+	})
+	if err != nil {
+		log.Printf("[PostProcess] Failed to schedule session %s: %v", sessionId, err)
+		return
+	}
+	p.enqueue(sessionId)
+}
+
+func (p *PostProcessor) enqueue(sessionId string) {
+	select {
+	case p.queue <- sessionId:
+	default:
+		log.Printf("[PostProcess] Queue full, session %s will be resumed on restart", sessionId)
+	}
+}
+
+func (p *PostProcessor) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-p.queue:
+			p.process(ctx, id)
+		}
+	}
+}
+
+func unfinished(status domain.ProcessingStatus) bool {
+	return status == domain.ProcessingPending || status == domain.ProcessingRunning
+}
+
+// process runs whichever stages have not completed yet.
+func (p *PostProcessor) process(ctx context.Context, sessionId string) {
+	session, err := p.repo.Get(ctx, sessionId)
+	if err != nil {
+		log.Printf("[PostProcess] Session %s vanished before processing: %v", sessionId, err)
+		return
+	}
+
+	if unfinished(session.TranscriptStatus) {
+		p.transcribe(ctx, sessionId)
+	}
+	p.summarize(ctx, sessionId)
+}
+
+func (p *PostProcessor) transcribe(ctx context.Context, sessionId string) {
+	session, err := p.repo.Get(ctx, sessionId)
+	if err != nil {
+		log.Printf("[PostProcess] Session %s vanished before transcription: %v", sessionId, err)
+		return
+	}
+
+	// Live captions already carry speaker names, so they win over STT
+	if len(session.Captions) > 0 {
+		p.storeTranscript(ctx, sessionId, session.Captions, domain.TranscriptFromCaptions)
+		return
+	}
+
+	audioPath := session.Artifacts[domain.ArtifactAudio]
+	if audioPath == "" {
+		_ = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+			s.TranscriptStatus = domain.ProcessingSkipped
+		})
+		return
+	}
+
+	_ = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+		s.TranscriptStatus = domain.ProcessingRunning
+	})
+
+	log.Printf("[PostProcess] Transcribing session %s (%s)", sessionId, audioPath)
+	segments, err := p.transcriber.Transcribe(ctx, audioPath)
+	if err != nil {
+		log.Printf("[PostProcess] Transcription failed for session %s: %v", sessionId, err)
+		_ = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+			s.TranscriptStatus = domain.ProcessingFailed
+			s.TranscriptError = err.Error()
+		})
+		p.events.Publish(domain.Event{Type: domain.EventTranscriptFailed, SessionID: sessionId, Error: err.Error()})
+		return
+	}
+
+	p.storeTranscript(ctx, sessionId, segments, domain.TranscriptFromSTT)
+}
+
+func (p *PostProcessor) storeTranscript(ctx context.Context, sessionId string, segments []domain.TranscriptSegment, source domain.TranscriptSource) {
+	err := p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+		s.TranscriptStatus = domain.ProcessingDone
+		s.TranscriptError = ""
+		s.TranscriptSource = source
+		s.Transcript = segments
+	})
+	if err != nil {
+		log.Printf("[PostProcess] Failed to store transcript for session %s: %v", sessionId, err)
+		return
+	}
+	log.Printf("[PostProcess] Transcript ready for session %s (%d segments from %s)", sessionId, len(segments), source)
+	p.events.Publish(domain.Event{Type: domain.EventTranscriptReady, SessionID: sessionId})
+}
+
+// update applies fn to the stored session. Stopped sessions are no longer
+// held by recordingService, so the repository is the only copy.
+func (p *PostProcessor) update(ctx context.Context, sessionId string, fn func(*domain.MeetingSession)) error {
+	session, err := p.repo.Get(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	fn(session)
+	return p.repo.Save(ctx, session)
+}
+
+func (p *PostProcessor) summarize(ctx context.Context, sessionId string) {
+	session, err := p.repo.Get(ctx, sessionId)
+	if err != nil || !unfinished(session.MinutesStatus) {
+		return
+	}
+
+	hasTranscript := session.TranscriptStatus == domain.ProcessingDone && len(session.Transcript) > 0
+	if !hasTranscript && len(session.Chat) == 0 {
+		_ = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+			s.MinutesStatus = domain.ProcessingSkipped
+		})
+		return
+	}
+
+	_ = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+		s.MinutesStatus = domain.ProcessingRunning
+	})
+
+	input := domain.MinutesInput{
+		MeetingURL:      session.MeetingURL,
+		Platform:        session.Platform,
+		StartTime:       session.StartTime,
+		Duration:        session.Duration,
+		ParticipantName: session.ParticipantName,
+		Transcript:      session.Transcript,
+		Chat:            session.Chat,
+	}
+
+	minutes, err := p.runSummarizers(ctx, sessionId, input)
+	if err != nil {
+		log.Printf("[PostProcess] Minutes failed for session %s: %v", sessionId, err)
+		_ = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+			s.MinutesStatus = domain.ProcessingFailed
+			s.MinutesError = err.Error()
+		})
+		p.events.Publish(domain.Event{Type: domain.EventMinutesFailed, SessionID: sessionId, Error: err.Error()})
+		return
+	}
+
+	err = p.update(ctx, sessionId, func(s *domain.MeetingSession) {
+		s.MinutesStatus = domain.ProcessingDone
+		s.MinutesError = ""
+		s.Minutes = minutes
+	})
+	if err != nil {
+		log.Printf("[PostProcess] Failed to store minutes for session %s: %v", sessionId, err)
+		return
+	}
+	log.Printf("[PostProcess] Minutes ready for session %s (by %s)", sessionId, minutes.GeneratedBy)
+	p.events.Publish(domain.Event{Type: domain.EventMinutesReady, SessionID: sessionId})
+}
+
+func (p *PostProcessor) runSummarizers(ctx context.Context, sessionId string, input domain.MinutesInput) (*domain.Minutes, error) {
+	summarizers := []ports.Summarizer{p.summarizer, p.fallback}
+
+	var lastErr error
+	for _, summarizer := range summarizers {
+		if summarizer == nil {
+			continue
+		}
+		minutes, err := summarizer.Summarize(ctx, input)
+		if err != nil {
+			log.Printf("[PostProcess] %s summarizer failed for session %s: %v", summarizer.Name(), sessionId, err)
+			lastErr = err
+			continue
+		}
+		minutes.GeneratedBy = summarizer.Name()
+		minutes.GeneratedAt = time.Now()
+		return minutes, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no summarizer configured")
+	}
+	return nil, lastErr
+}
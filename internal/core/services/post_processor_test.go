@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/adapters/secondary/fake"
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/adapters/secondary/openai"
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// writeTestWAV writes a silent 8 kHz, 8-bit mono WAV file of the given length.
+func writeTestWAV(t *testing.T, length time.Duration) string {
+	t.Helper()
+	const byteRate = 8000
+	data := make([]byte, int(length.Seconds()*byteRate))
+
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+len(data)))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(header[22:], 1) // Mono
+	binary.LittleEndian.PutUint32(header[24:], 8000)
+	binary.LittleEndian.PutUint32(header[28:], byteRate)
+	binary.LittleEndian.PutUint16(header[32:], 1)
+	binary.LittleEndian.PutUint16(header[34:], 8)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(len(data)))
+
+	path := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(path, append(header, data...), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// stoppedSession saves a stopped session as the recording service leaves it.
+func stoppedSession(t *testing.T, repo ports.SessionRepository, mutate func(*domain.MeetingSession)) *domain.MeetingSession {
+	t.Helper()
+	start := time.Now().Add(-time.Minute)
+	session := &domain.MeetingSession{
+		ID:              "session-1",
+		MeetingURL:      "https://meet.google.com/abc-defg-hij",
+		Platform:        domain.PlatformMeet,
+		ParticipantName: "Minutes Bot",
+		Status:          domain.StatusStopped,
+		CreatedAt:       start,
+		StartTime:       &start,
+	}
+	if mutate != nil {
+		mutate(session)
+	}
+	if err := repo.Save(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+// postProcess runs both stages for the session the way the worker does and
+// returns the stored result and the events published meanwhile.
+func postProcess(t *testing.T, p *PostProcessor, sessionId string) (*domain.MeetingSession, []domain.EventType) {
+	t.Helper()
+	events, cancel := p.events.Subscribe(sessionId)
+	ctx := context.Background()
+	p.schedule(ctx, sessionId)
+	p.process(ctx, sessionId)
+	cancel()
+
+	var types []domain.EventType
+	for event := range events {
+		types = append(types, event.Type)
+	}
+	session, err := p.repo.Get(ctx, sessionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session, types
+}
+
+func TestPostProcessorTranscribesAudio(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	audio := writeTestWAV(t, 25*time.Second)
+	stoppedSession(t, repo, func(s *domain.MeetingSession) {
+		s.AddArtifacts(domain.Artifacts{domain.ArtifactAudio: audio})
+	})
+	p := NewPostProcessor(repo, NewEventBus(), fake.NewTranscriber(), nil, NewRuleBasedSummarizer())
+
+	session, events := postProcess(t, p, "session-1")
+
+	if session.TranscriptStatus != domain.ProcessingDone || session.TranscriptSource != domain.TranscriptFromSTT {
+		t.Fatalf("transcript status %s from %s, want done from stt (error %q)",
+			session.TranscriptStatus, session.TranscriptSource, session.TranscriptError)
+	}
+	// 25s of audio in 10s segments
+	if n := len(session.Transcript); n != 3 {
+		t.Fatalf("got %d segments, want 3", n)
+	}
+	if last := session.Transcript[2]; last.Start != 20*time.Second || last.End < 24*time.Second {
+		t.Errorf("last segment spans %s-%s, want 20s to the end of the audio", last.Start, last.End)
+	}
+	if session.MinutesStatus != domain.ProcessingDone || session.Minutes == nil || session.Minutes.GeneratedBy != "rules" {
+		t.Errorf("minutes status %s (error %q), want done by the fallback", session.MinutesStatus, session.MinutesError)
+	}
+	want := []domain.EventType{domain.EventTranscriptReady, domain.EventMinutesReady}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Errorf("events %v, want %v", events, want)
+	}
+}
+
+func TestPostProcessorPrefersCaptions(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	captions := []domain.TranscriptSegment{
+		{Start: 0, End: 3 * time.Second, Speaker: "Ann", Text: "We decided to ship on Friday."},
+		{Start: 3 * time.Second, End: 6 * time.Second, Speaker: "Bob", Text: "Bob will send the notes."},
+	}
+	stoppedSession(t, repo, func(s *domain.MeetingSession) {
+		s.Captions = captions
+		// Captions win even though there is audio the transcriber could use
+		s.AddArtifacts(domain.Artifacts{domain.ArtifactAudio: filepath.Join(t.TempDir(), "missing.wav")})
+	})
+	p := NewPostProcessor(repo, NewEventBus(), fake.NewTranscriber(), nil, NewRuleBasedSummarizer())
+
+	session, _ := postProcess(t, p, "session-1")
+
+	if session.TranscriptSource != domain.TranscriptFromCaptions {
+		t.Fatalf("transcript from %s, want captions", session.TranscriptSource)
+	}
+	if len(session.Transcript) != 2 || session.Transcript[0].Speaker != "Ann" {
+		t.Errorf("transcript %+v, want the captions", session.Transcript)
+	}
+}
+
+func TestPostProcessorTranscriptionFailure(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	stoppedSession(t, repo, func(s *domain.MeetingSession) {
+		s.AddArtifacts(domain.Artifacts{domain.ArtifactAudio: filepath.Join(t.TempDir(), "missing.wav")})
+	})
+	p := NewPostProcessor(repo, NewEventBus(), fake.NewTranscriber(), nil, NewRuleBasedSummarizer())
+
+	session, events := postProcess(t, p, "session-1")
+
+	if session.TranscriptStatus != domain.ProcessingFailed || session.TranscriptError == "" {
+		t.Errorf("transcript status %s (error %q), want failed with an error", session.TranscriptStatus, session.TranscriptError)
+	}
+	// Nothing to summarize without a transcript or chat
+	if session.MinutesStatus != domain.ProcessingSkipped {
+		t.Errorf("minutes status %s, want skipped", session.MinutesStatus)
+	}
+	if len(events) != 1 || events[0] != domain.EventTranscriptFailed {
+		t.Errorf("events %v, want [%s]", events, domain.EventTranscriptFailed)
+	}
+}
+
+func TestPostProcessorSkipsWithoutAudio(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	stoppedSession(t, repo, nil)
+	p := NewPostProcessor(repo, NewEventBus(), fake.NewTranscriber(), nil, NewRuleBasedSummarizer())
+
+	session, _ := postProcess(t, p, "session-1")
+
+	if session.TranscriptStatus != domain.ProcessingSkipped || session.MinutesStatus != domain.ProcessingSkipped {
+		t.Errorf("statuses %s/%s, want skipped/skipped", session.TranscriptStatus, session.MinutesStatus)
+	}
+}
+
+// summarizerStub answers /chat/completions like an OpenAI-compatible server.
+type summarizerStub struct {
+	status  int
+	content string // The model's reply
+	prompts []string
+}
+
+func (s *summarizerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if r.URL.Path != "/v1/chat/completions" || json.NewDecoder(r.Body).Decode(&req) != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	for _, m := range req.Messages {
+		if m.Role == "user" {
+			s.prompts = append(s.prompts, m.Content)
+		}
+	}
+	if s.status != http.StatusOK {
+		http.Error(w, "model unavailable", s.status)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": s.content}}},
+	})
+}
+
+func newSummarizerStub(t *testing.T, status int, content string) (*summarizerStub, ports.Summarizer) {
+	t.Helper()
+	stub := &summarizerStub{status: status, content: content}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, openai.NewSummarizer(openai.Config{BaseURL: server.URL + "/v1", Model: "stub"})
+}
+
+func captionedSession(t *testing.T, repo ports.SessionRepository) {
+	t.Helper()
+	stoppedSession(t, repo, func(s *domain.MeetingSession) {
+		s.Captions = []domain.TranscriptSegment{
+			{Start: 0, End: 3 * time.Second, Speaker: "Ann", Text: "We decided to ship on Friday."},
+			{Start: 3 * time.Second, End: 6 * time.Second, Speaker: "Bob", Text: "Bob will send the notes by Monday."},
+		}
+		s.Chat = []domain.ChatMessage{{Sender: "Ann", Text: "Release checklist", Links: []string{"https://example.com/checklist"}}}
+	})
+}
+
+func TestPostProcessorSummarizesWithModel(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	captionedSession(t, repo)
+	stub, summarizer := newSummarizerStub(t, http.StatusOK, "```json\n"+`{
+		"summary": "The team agreed to ship on Friday.",
+		"decisions": ["Ship on Friday"],
+		"actionItems": [{"description": "Send the notes", "owner": "Bob", "dueDate": "Monday"}],
+		"openQuestions": []
+	}`+"\n```")
+	p := NewPostProcessor(repo, NewEventBus(), fake.NewTranscriber(), summarizer, NewRuleBasedSummarizer())
+
+	session, _ := postProcess(t, p, "session-1")
+
+	if session.MinutesStatus != domain.ProcessingDone || session.Minutes == nil {
+		t.Fatalf("minutes status %s (error %q), want done", session.MinutesStatus, session.MinutesError)
+	}
+	minutes := session.Minutes
+	if minutes.GeneratedBy != "openai:stub" {
+		t.Errorf("generated by %q, want the model", minutes.GeneratedBy)
+	}
+	if len(minutes.ActionItems) != 1 || minutes.ActionItems[0].Owner != "Bob" || minutes.ActionItems[0].DueDate != "Monday" {
+		t.Errorf("action items %+v", minutes.ActionItems)
+	}
+	if len(stub.prompts) != 1 {
+		t.Fatalf("model was called %d times, want 1", len(stub.prompts))
+	}
+	for _, want := range []string{"Ann: We decided to ship on Friday.", "Bob: Bob will send the notes", "https://example.com/checklist"} {
+		if !strings.Contains(stub.prompts[0], want) {
+			t.Errorf("prompt is missing %q:\n%s", want, stub.prompts[0])
+		}
+	}
+}
+
+func TestPostProcessorFallsBackWhenModelFails(t *testing.T) {
+	for name, stubbed := range map[string]struct {
+		status  int
+		content string
+	}{
+		"server error":  {http.StatusServiceUnavailable, ""},
+		"invalid reply": {http.StatusOK, "Here are your minutes!"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := memory.NewSessionRepository()
+			captionedSession(t, repo)
+			stub, summarizer := newSummarizerStub(t, stubbed.status, stubbed.content)
+			p := NewPostProcessor(repo, NewEventBus(), fake.NewTranscriber(), summarizer, NewRuleBasedSummarizer())
+
+			session, events := postProcess(t, p, "session-1")
+
+			if len(stub.prompts) != 1 {
+				t.Errorf("model was called %d times, want 1", len(stub.prompts))
+			}
+			if session.MinutesStatus != domain.ProcessingDone || session.Minutes == nil || session.Minutes.GeneratedBy != "rules" {
+				t.Fatalf("minutes status %s (error %q), want done by the fallback", session.MinutesStatus, session.MinutesError)
+			}
+			if len(session.Minutes.Decisions) == 0 {
+				t.Errorf("fallback found no decisions in %+v", session.Minutes)
+			}
+			if events[len(events)-1] != domain.EventMinutesReady {
+				t.Errorf("events %v, want %s last", events, domain.EventMinutesReady)
+			}
+		})
+	}
+}
+
+func TestPostProcessorSchedulesEveryStoppedSession(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	events := NewEventBus()
+	p := NewPostProcessor(repo, events, fake.NewTranscriber(), nil, NewRuleBasedSummarizer())
+
+	// More than a subscriber's buffer stop at once; none may be missed
+	const n = 2 * subscriberBuffer
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("session-%d", i)
+		if err := repo.Save(context.Background(), &domain.MeetingSession{ID: id, Status: domain.StatusStopped}); err != nil {
+			t.Fatal(err)
+		}
+		events.Publish(domain.Event{Type: domain.EventStatusChanged, SessionID: id, Status: domain.StatusStopped})
+	}
+
+	if got := len(p.queue); got != n {
+		t.Errorf("%d sessions queued, want %d", got, n)
+	}
+	for i := 0; i < n; i++ {
+		session, _ := repo.Get(context.Background(), fmt.Sprintf("session-%d", i))
+		if session.TranscriptStatus != domain.ProcessingPending || session.MinutesStatus != domain.ProcessingPending {
+			t.Fatalf("%s not marked pending: transcript %q, minutes %q", session.ID, session.TranscriptStatus, session.MinutesStatus)
+		}
+	}
+}
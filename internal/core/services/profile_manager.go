@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+// ProfileManager keeps the bot's signed-in identities. A profile is used by
+// one session at a time, since its browser data cannot be shared by two
+// running browsers. Joins report back whether the login still works.
+type ProfileManager struct {
+	repo  ports.ProfileRepository
+	store ports.IdentityStore
+
+	mu    sync.Mutex
+	inUse map[string]profileClaim // By profile ID
+}
+
+// profileClaim is a session's hold on a profile. stateUpdatedAt is the
+// profile's login as of the claim, so a join failing with that login doesn't
+// expire one uploaded since.
+type profileClaim struct {
+	sessionId      string
+	stateUpdatedAt time.Time
+}
+
+func NewProfileManager(repo ports.ProfileRepository, store ports.IdentityStore) *ProfileManager {
+	return &ProfileManager{
+		repo:  repo,
+		store: store,
+		inUse: make(map[string]profileClaim),
+	}
+}
+
+func (m *ProfileManager) CreateProfile(ctx context.Context, req domain.ProfileRequest) (*domain.BotProfile, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	profile := &domain.BotProfile{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(req.Name),
+		Platform:  req.Platform,
+		Status:    domain.ProfileNew,
+		CreatedAt: time.Now(),
+	}
+	if err := m.repo.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save profile: %w", err)
+	}
+	return profile, nil
+}
+
+func (m *ProfileManager) GetProfile(ctx context.Context, profileId string) (*domain.BotProfile, error) {
+	return m.repo.Get(ctx, profileId)
+}
+
+func (m *ProfileManager) ListProfiles(ctx context.Context) ([]*domain.BotProfile, error) {
+	return m.repo.List(ctx)
+}
+
+func (m *ProfileManager) DeleteProfile(ctx context.Context, profileId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.repo.Get(ctx, profileId); err != nil {
+		return err
+	}
+	if claim, ok := m.inUse[profileId]; ok {
+		return fmt.Errorf("%w: session %s", domain.ErrProfileBusy, claim.sessionId)
+	}
+	if err := m.store.DeleteIdentity(ctx, profileId); err != nil {
+		return fmt.Errorf("failed to delete profile data: %w", err)
+	}
+	return m.repo.Delete(ctx, profileId)
+}
+
+// UpdateStorageState stores a fresh login. It takes effect on the profile's
+// next join, so a session already using the profile keeps its login.
+func (m *ProfileManager) UpdateStorageState(ctx context.Context, profileId string, state domain.StorageState) (*domain.BotProfile, error) {
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, err := m.repo.Get(ctx, profileId)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.SaveStorageState(ctx, profileId, state); err != nil {
+		return nil, fmt.Errorf("failed to store login: %w", err)
+	}
+
+	now := time.Now()
+	profile.Status = domain.ProfileActive
+	profile.StateUpdatedAt = &now
+	profile.ExpiredAt = nil
+	profile.LastError = ""
+	if err := m.repo.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save profile: %w", err)
+	}
+	return profile, nil
+}
+
+// claim reserves a profile for a session joining on platform. Only profiles
+// with a working login can be claimed.
+func (m *ProfileManager) claim(ctx context.Context, profileId string, platform domain.Platform, sessionId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, err := m.repo.Get(ctx, profileId)
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return fmt.Errorf("%w: unknown profileId %q", domain.ErrInvalidRequest, profileId)
+	}
+	if err != nil {
+		return err
+	}
+	if profile.Platform != platform {
+		return fmt.Errorf("%w: profile %s is for %s, not %s", domain.ErrInvalidRequest, profileId, profile.Platform, platform)
+	}
+	if profile.Status != domain.ProfileActive {
+		return fmt.Errorf("%w: profile %s is %s; upload a fresh storage state", domain.ErrProfileSignedOut, profileId, profile.Status)
+	}
+	if other, ok := m.inUse[profileId]; ok {
+		return fmt.Errorf("%w: session %s", domain.ErrProfileBusy, other.sessionId)
+	}
+	claim := profileClaim{sessionId: sessionId}
+	if profile.StateUpdatedAt != nil {
+		claim.stateUpdatedAt = *profile.StateUpdatedAt
+	}
+	m.inUse[profileId] = claim
+	return nil
+}
+
+// release frees the profile once the session that claimed it has ended.
+func (m *ProfileManager) release(profileId, sessionId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.inUse[profileId].sessionId == sessionId {
+		delete(m.inUse, profileId)
+	}
+}
+
+// signedIn records a join that got into the meeting with the profile's login.
+func (m *ProfileManager) signedIn(ctx context.Context, profileId string) {
+	m.update(ctx, profileId, func(profile *domain.BotProfile) {
+		now := time.Now()
+		profile.LastUsedAt = &now
+	})
+}
+
+// signedOut marks the profile expired after the session's join found its
+// login no longer works. A login uploaded since the session claimed the
+// profile is left for the next join to try.
+func (m *ProfileManager) signedOut(ctx context.Context, profileId, sessionId string, cause error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, err := m.repo.Get(ctx, profileId)
+	if err != nil {
+		log.Printf("[Profiles] Failed to load profile %s: %v", profileId, err)
+		return
+	}
+	claim, ok := m.inUse[profileId]
+	if ok && claim.sessionId == sessionId && profile.StateUpdatedAt != nil && !profile.StateUpdatedAt.Equal(claim.stateUpdatedAt) {
+		log.Printf("[Profiles] Session %s was signed out of profile %s, but a new login has been uploaded since: %v", sessionId, profileId, cause)
+		return
+	}
+
+	log.Printf("[Profiles] Login for profile %s has expired: %v", profileId, cause)
+	now := time.Now()
+	profile.Status = domain.ProfileExpired
+	profile.ExpiredAt = &now
+	profile.LastError = cause.Error()
+	if err := m.repo.Save(ctx, profile); err != nil {
+		log.Printf("[Profiles] Failed to save profile %s: %v", profileId, err)
+	}
+}
+
+func (m *ProfileManager) update(ctx context.Context, profileId string, mutate func(*domain.BotProfile)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, err := m.repo.Get(ctx, profileId)
+	if err != nil {
+		log.Printf("[Profiles] Failed to load profile %s: %v", profileId, err)
+		return
+	}
+	mutate(profile)
+	if err := m.repo.Save(ctx, profile); err != nil {
+		log.Printf("[Profiles] Failed to save profile %s: %v", profileId, err)
+	}
+}
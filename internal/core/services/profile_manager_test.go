@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// nopIdentityStore accepts logins without keeping them.
+type nopIdentityStore struct{}
+
+func (nopIdentityStore) SaveStorageState(ctx context.Context, profileId string, state domain.StorageState) error {
+	return nil
+}
+
+func (nopIdentityStore) DeleteIdentity(ctx context.Context, profileId string) error { return nil }
+
+var errTestSignedOut = errors.New("sign-in page shown")
+
+func newActiveProfile(t *testing.T) (*ProfileManager, *domain.BotProfile) {
+	t.Helper()
+	m := NewProfileManager(memory.NewProfileRepository(), nopIdentityStore{})
+	ctx := context.Background()
+	profile, err := m.CreateProfile(ctx, domain.ProfileRequest{Name: "Minutes Bot", Platform: domain.PlatformTeams})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile, err = m.UpdateStorageState(ctx, profile.ID, testLogin()); err != nil {
+		t.Fatal(err)
+	}
+	return m, profile
+}
+
+func testLogin() domain.StorageState {
+	return domain.StorageState{Cookies: []domain.StorageCookie{{Name: "session", Value: "v", Domain: ".example.com", Path: "/"}}}
+}
+
+func TestSignedOutExpiresProfile(t *testing.T) {
+	m, profile := newActiveProfile(t)
+	ctx := context.Background()
+	if err := m.claim(ctx, profile.ID, domain.PlatformTeams, "s1"); err != nil {
+		t.Fatal(err)
+	}
+
+	m.signedOut(ctx, profile.ID, "s1", errTestSignedOut)
+
+	got, _ := m.GetProfile(ctx, profile.ID)
+	if got.Status != domain.ProfileExpired || got.LastError == "" {
+		t.Errorf("status %s (error %q), want expired with the cause", got.Status, got.LastError)
+	}
+}
+
+func TestSignedOutKeepsLoginUploadedDuringSession(t *testing.T) {
+	m, profile := newActiveProfile(t)
+	ctx := context.Background()
+	if err := m.claim(ctx, profile.ID, domain.PlatformTeams, "s1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The user uploads a new login while the session's join is failing with the old one
+	time.Sleep(time.Millisecond)
+	if _, err := m.UpdateStorageState(ctx, profile.ID, testLogin()); err != nil {
+		t.Fatal(err)
+	}
+	m.signedOut(ctx, profile.ID, "s1", errTestSignedOut)
+	m.release(profile.ID, "s1")
+
+	got, _ := m.GetProfile(ctx, profile.ID)
+	if got.Status != domain.ProfileActive {
+		t.Errorf("status %s, want the new login to stay %s", got.Status, domain.ProfileActive)
+	}
+	if err := m.claim(ctx, profile.ID, domain.PlatformTeams, "s2"); err != nil {
+		t.Errorf("next session could not claim the profile: %v", err)
+	}
+}
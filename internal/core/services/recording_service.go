@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,115 +16,381 @@ import (
 )
 
 type recordingService struct {
-	sessions      map[string]*domain.MeetingSession
+	sessions      map[string]*domain.MeetingSession // Live sessions only; finished ones are read from repo
+	runs          map[string]*sessionRun            // Keyed like sessions
+	dirty         map[string]bool                   // Live sessions with changes not yet persisted
 	mu            sync.RWMutex
-	automator     ports.BrowserAutomator
+	repo          ports.SessionRepository
+	platforms     *PlatformRegistry
 	mediaRecorder ports.MediaRecorder
+	events        *EventBus
+	profiles      *ProfileManager
 }
 
-func NewRecordingService(automator ports.BrowserAutomator, mediaRecorder ports.MediaRecorder) ports.RecordingService {
-	return &recordingService{
+// sessionRun owns everything a live session started: its context bounds the
+// join, the capture goroutines and the browser launch.
+type sessionRun struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	automator ports.BrowserAutomator
+	done      chan struct{} // Closed once the join/record pipeline returns
+	recording bool          // The media recorder was started; guarded by s.mu
+
+	// Inputs to the session's StopPolicy, guarded by s.mu
+	meter      *audioMeter // nil when the session has no audio
+	aloneSince time.Time   // Zero unless the last roster sample was empty
+	rosterSeen bool        // A roster sample has listed someone
+}
+
+const (
+	// durationTickInterval is how often recording sessions publish EventDurationTick
+	durationTickInterval = 5 * time.Second
+	// pipelineExitTimeout bounds how long a stop waits for an aborted join to unwind
+	pipelineExitTimeout = 30 * time.Second
+	// finalizeTimeout bounds how long ffmpeg gets to finish its outputs
+	finalizeTimeout = 60 * time.Second
+)
+
+func NewRecordingService(platforms *PlatformRegistry, mediaRecorder ports.MediaRecorder, repo ports.SessionRepository, events *EventBus, profiles *ProfileManager) ports.RecordingService {
+	s := &recordingService{
 		sessions:      make(map[string]*domain.MeetingSession),
-		automator:     automator,
+		runs:          make(map[string]*sessionRun),
+		dirty:         make(map[string]bool),
+		repo:          repo,
+		platforms:     platforms,
 		mediaRecorder: mediaRecorder,
+		events:        events,
+		profiles:      profiles,
+	}
+	s.markInterrupted(context.Background())
+
+	for _, automator := range platforms.automators {
+		automator.SetObserver(s)
+	}
+	go s.watchRecordings()
+
+	return s
+}
+
+// markInterrupted flags sessions that were still active when the previous
+// process exited. Their browser and recorder are gone, but the record stays
+// so the session can be found and audited.
+func (s *recordingService) markInterrupted(ctx context.Context) {
+	sessions, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("[Service] Failed to load sessions for recovery: %v", err)
+		return
+	}
+
+	for _, session := range sessions {
+		if !session.Status.IsActive() {
+			continue
+		}
+		log.Printf("[Service] Marking session %s as interrupted (was %s)", session.ID, session.Status)
+		session.Error = fmt.Sprintf("service restarted while session was %s", session.Status)
+		if err := session.Transition(domain.StatusInterrupted); err != nil {
+			log.Printf("[Service] %v", err)
+			continue
+		}
+		if err := s.repo.Save(ctx, session); err != nil {
+			log.Printf("[Service] Failed to mark session %s as interrupted: %v", session.ID, err)
+		}
 	}
 }
 
-func (s *recordingService) StartRecording(ctx context.Context, meetingUrl, participantName string) (*domain.MeetingSession, error) {
+func (s *recordingService) StartRecording(ctx context.Context, req domain.StartRequest) (*domain.MeetingSession, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	platform, automator, err := s.platforms.Resolve(req.MeetingURL)
+	if err != nil {
+		return nil, err
+	}
+
 	id := uuid.New().String()
 	session := &domain.MeetingSession{
-		ID:              id,
-		MeetingURL:      meetingUrl,
-		ParticipantName: participantName,
-		Status:          domain.StatusInitializing,
-		StartTime:       nil,
+		ID:                  id,
+		MeetingURL:          req.MeetingURL,
+		Platform:            platform,
+		ParticipantName:     req.ParticipantName,
+		SeriesID:            req.SeriesID,
+		LobbyTimeoutMinutes: req.LobbyTimeoutMinutes,
+		StopPolicy:          req.StopPolicy,
+		Locale:              req.Locale,
+		ProfileID:           req.ProfileID,
+		CallbackURLs:        req.CallbackURLs,
+		Status:              domain.StatusInitializing,
+		CreatedAt:           time.Now(),
+		StartTime:           nil,
+	}
+
+	// A profile is held until the session ends; its browser data can only be
+	// open in one browser
+	if session.ProfileID != "" {
+		if err := s.profiles.claim(ctx, session.ProfileID, platform, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Save(ctx, session); err != nil {
+		if session.ProfileID != "" {
+			s.profiles.release(session.ProfileID, id)
+		}
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	// The run outlives the request that started it, so it gets its own context
+	runCtx, cancel := context.WithCancel(context.Background())
+	run := &sessionRun{
+		ctx:       runCtx,
+		cancel:    cancel,
+		automator: automator,
+		done:      make(chan struct{}),
 	}
 
 	s.mu.Lock()
 	s.sessions[id] = session
+	s.runs[id] = run
 	s.mu.Unlock()
 
-	// Launch async process to join and record
-	go func() {
-		// Create a background context for the long-running task
-		bgCtx := context.Background()
+	go s.runPipeline(id, session, run)
+
+	return session, nil
+}
+
+// runPipeline joins the meeting and starts recording. A stop request cancels
+// run.ctx; the pipeline then returns without touching the session and leaves
+// cleanup to the stopper.
+func (s *recordingService) runPipeline(id string, session *domain.MeetingSession, run *sessionRun) {
+	defer close(run.done)
 
-		// 1. Join Meeting
-		s.updateStatus(id, domain.StatusJoining)
-		err := s.automator.JoinMeeting(bgCtx, session)
-		if err != nil {
-			s.updateError(id, fmt.Sprintf("Failed to join: %v", err))
-			return
+	if err := s.transition(id, domain.StatusJoining, nil); err != nil {
+		return
+	}
+
+	if err := run.automator.JoinMeeting(run.ctx, session); err != nil {
+		switch {
+		case run.ctx.Err() != nil:
+			// Stopped while joining; the stop owns the cleanup
+		case errors.Is(err, domain.ErrAdmissionDenied):
+			s.end(id, domain.StatusDenied, err.Error())
+		case errors.Is(err, domain.ErrProfileSignedOut):
+			s.profiles.signedOut(context.Background(), session.ProfileID, id, err)
+			s.fail(id, fmt.Sprintf("Failed to join: %v", err))
+		default:
+			s.fail(id, fmt.Sprintf("Failed to join: %v", err))
 		}
+		return
+	}
 
-		// Start Recording
-		s.updateStatus(id, domain.StatusRecording)
+	if err := s.transition(id, domain.StatusAdmitted, nil); err != nil {
+		return
+	}
+	if session.ProfileID != "" {
+		s.profiles.signedIn(context.Background(), session.ProfileID)
+	}
+
+	// The clock starts with the recorder, not when the join was requested
+	err := s.transition(id, domain.StatusRecording, func(session *domain.MeetingSession) {
 		now := time.Now()
 		session.StartTime = &now
+	})
+	if err != nil {
+		return
+	}
 
-		// Start recording streams
-		go func() {
-			video, audio, err := s.automator.GetMeetingStreams(bgCtx, id)
-			if err != nil {
-				s.updateError(id, fmt.Sprintf("Failed to get streams: %v", err))
-				return
-			}
+	video, audio, err := run.automator.GetMeetingStreams(run.ctx, id)
+	if err != nil {
+		s.fail(id, fmt.Sprintf("Failed to get streams: %v", err))
+		return
+	}
+	if audio != nil {
+		meter := newAudioMeter(audio)
+		s.mu.Lock()
+		run.meter = meter
+		s.mu.Unlock()
+		audio = meter
+	}
 
-			if err := s.mediaRecorder.Start(bgCtx, id, video, audio); err != nil {
-				s.updateError(id, fmt.Sprintf("Recorder failed: %v", err))
-			}
-		}()
-	}()
+	if err := s.mediaRecorder.Start(run.ctx, id, video, audio); err != nil {
+		s.fail(id, fmt.Sprintf("Recorder failed: %v", err))
+		return
+	}
+	s.mu.Lock()
+	run.recording = true
+	s.mu.Unlock()
+}
+
+// StopRecording ends a live session at whatever phase it is in. A session
+// stopped before it was recording ends as cancelled, otherwise as stopped.
+func (s *recordingService) StopRecording(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+	return s.stop(ctx, sessionId, domain.StopRequested)
+}
 
+// stop runs the stop pipeline and records why the session ended.
+func (s *recordingService) stop(ctx context.Context, sessionId string, reason domain.StopReason) (*domain.MeetingSession, error) {
+	s.mu.Lock()
+	session, exists := s.sessions[sessionId]
+	run := s.runs[sessionId]
+	if !exists {
+		s.mu.Unlock()
+		// Not live - may still be a finished session from history
+		return s.repo.Get(ctx, sessionId)
+	}
+
+	wasRecording := session.Status == domain.StatusRecording
+	if run == nil {
+		// Already being torn down; the first stop request finishes the job
+		s.mu.Unlock()
+		return session, nil
+	}
+	err := s.transitionLocked(session, domain.StatusStopping, func(session *domain.MeetingSession) {
+		session.StopReason = reason
+	})
+	if err != nil {
+		// Already stopping; the first stop request finishes the job
+		s.mu.Unlock()
+		return session, nil
+	}
+	s.mu.Unlock()
+
+	if !wasRecording {
+		// Abort the join; the pipeline returns once the automator notices
+		run.cancel()
+	}
+	select {
+	case <-run.done:
+	case <-time.After(pipelineExitTimeout):
+		log.Printf("[Service] Session %s pipeline did not exit within %s, cleaning up anyway", sessionId, pipelineExitTimeout)
+	}
+
+	artifacts, recErr := s.teardown(sessionId, run)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if recErr != nil {
+		s.transitionLocked(session, domain.StatusError, func(session *domain.MeetingSession) {
+			session.Error = fmt.Sprintf("Failed to stop recorder: %v", recErr)
+			session.AddArtifacts(artifacts)
+		})
+		return session, recErr
+	}
+
+	final := domain.StatusCancelled
+	if wasRecording {
+		final = domain.StatusStopped
+	}
+	s.transitionLocked(session, final, func(session *domain.MeetingSession) {
+		now := time.Now()
+		session.AddArtifacts(artifacts)
+		session.EndTime = &now
+		session.CalculateDuration()
+	})
 	return session, nil
 }
 
-func (s *recordingService) StopRecording(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+// Shutdown stops every live session so recordings are finalized rather than
+// left for markInterrupted on the next start.
+func (s *recordingService) Shutdown(ctx context.Context) error {
 	s.mu.RLock()
-	session, exists := s.sessions[sessionId]
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
 	s.mu.RUnlock()
 
-	if !exists {
-		return nil, fmt.Errorf("session not found")
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if _, err := s.stop(ctx, id, domain.StopShutdown); err != nil {
+				log.Printf("[Service] Failed to stop session %s during shutdown: %v", id, err)
+			}
+		}(id)
 	}
 
-	if session.Status != domain.StatusRecording {
-		return session, nil
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	s.updateStatus(sessionId, domain.StatusStopping)
+// teardown releases everything the run holds: the recorder is finalized
+// first so its outputs are complete, then the browser is closed and the run
+// context cancelled, which ends any capture goroutine still running. The
+// run stays registered until the caller's terminal transition drops it.
+func (s *recordingService) teardown(sessionId string, run *sessionRun) (domain.Artifacts, error) {
+	s.mu.Lock()
+	recording := run.recording
+	run.recording = false
+	s.mu.Unlock()
 
-	// Stop recorder
-	path, err := s.mediaRecorder.Stop(ctx, sessionId)
-	if err != nil {
-		s.updateError(sessionId, fmt.Sprintf("Failed to stop recorder: %v", err))
-		return session, err
+	var artifacts domain.Artifacts
+	var recErr error
+	if recording {
+		ctx, cancel := context.WithTimeout(context.Background(), finalizeTimeout)
+		artifacts, recErr = s.mediaRecorder.Stop(ctx, sessionId)
+		cancel()
 	}
-	session.FilePath = path
 
-	// Stop browser
-	err = s.automator.StopMeeting(ctx, sessionId)
-	if err != nil {
-		// logging warning usually
+	if err := run.automator.StopMeeting(context.Background(), sessionId); err != nil {
+		log.Printf("[Service] Failed to close browser for session %s: %v", sessionId, err)
 	}
+	run.cancel()
+	return artifacts, recErr
+}
 
-	now := time.Now()
-	session.EndTime = &now
-	session.CalculateDuration()
-	s.updateStatus(sessionId, domain.StatusStopped)
+// fail moves a live session to error and releases its resources. Whatever
+// the recorder managed to write is kept as the session's artifacts.
+func (s *recordingService) fail(sessionId string, msg string) {
+	s.end(sessionId, domain.StatusError, msg)
+}
 
-	return session, nil
+// end releases a live session's resources and moves it to a terminal status
+// other than stopped or cancelled.
+func (s *recordingService) end(sessionId string, status domain.SessionStatus, msg string) {
+	s.mu.Lock()
+	session, ok := s.sessions[sessionId]
+	run := s.runs[sessionId]
+	if !ok || run == nil || session.Status == domain.StatusStopping {
+		// Gone, or a stop request is already cleaning up
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	artifacts, _ := s.teardown(sessionId, run)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transitionLocked(session, status, func(session *domain.MeetingSession) {
+		session.Error = msg
+		session.AddArtifacts(artifacts)
+	})
 }
 
 func (s *recordingService) GetSessionPlatform(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	session, exists := s.sessions[sessionId]
+	s.mu.RUnlock()
+
 	if !exists {
-		return nil, fmt.Errorf("session not found")
+		return s.repo.Get(ctx, sessionId)
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Recalculate duration if ongoing
 	if session.Status == domain.StatusRecording && session.StartTime != nil {
 		duration := time.Since(*session.StartTime)
@@ -131,19 +400,324 @@ func (s *recordingService) GetSessionPlatform(ctx context.Context, sessionId str
 	return session, nil
 }
 
-func (s *recordingService) updateStatus(id string, status domain.SessionStatus) {
+func (s *recordingService) ListSessions(ctx context.Context, filter domain.SessionFilter) (*domain.SessionPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(sessions, filter)
+}
+
+func (s *recordingService) GetArtifactPath(ctx context.Context, sessionId string, kind domain.ArtifactKind) (string, error) {
+	session, err := s.GetSessionPlatform(ctx, sessionId)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.RLock()
+	path, ok := session.Artifacts[kind]
+	s.mu.RUnlock()
+
+	if !ok || path == "" {
+		return "", domain.ErrArtifactNotFound
+	}
+	return path, nil
+}
+
+func (s *recordingService) DeleteSession(ctx context.Context, sessionId string) error {
+	s.mu.RLock()
+	_, live := s.sessions[sessionId]
+	s.mu.RUnlock()
+
+	if live {
+		return domain.ErrSessionActive
+	}
+
+	if _, err := s.repo.Get(ctx, sessionId); err != nil {
+		return err
+	}
+
+	if err := s.mediaRecorder.DeleteArtifacts(ctx, sessionId); err != nil {
+		return fmt.Errorf("failed to delete recording artifacts: %w", err)
+	}
+	return s.repo.Delete(ctx, sessionId)
+}
+
+func (s *recordingService) SubscribeEvents(ctx context.Context, sessionId string) (<-chan domain.Event, error) {
+	if sessionId != "" {
+		if _, err := s.GetSessionPlatform(ctx, sessionId); err != nil {
+			return nil, err
+		}
+	}
+
+	events, cancel := s.events.Subscribe(sessionId)
+	go func() {
+		<-ctx.Done()
+		cancel()
+	}()
+	return events, nil
+}
+
+// MeetingEnded implements ports.MeetingObserver. The automator only reports
+// what it saw; finalizing the recording is the same stop pipeline the API uses.
+func (s *recordingService) MeetingEnded(sessionId string, reason domain.StopReason) {
+	log.Printf("[Service] Session %s ended by platform: %s", sessionId, reason)
+	s.autoStop(sessionId, reason)
+}
+
+// autoStop stops a session that ended without a stop request.
+func (s *recordingService) autoStop(sessionId string, reason domain.StopReason) {
+	s.events.Publish(domain.Event{
+		Type:      domain.EventAutoStopDetected,
+		SessionID: sessionId,
+		Reason:    string(reason),
+	})
+
+	// Finalizing can take a while; don't hold up the caller's loop
+	go func() {
+		if _, err := s.stop(context.Background(), sessionId, reason); err != nil {
+			log.Printf("[Service] Failed to stop session %s after %s: %v", sessionId, reason, err)
+		}
+	}()
+}
+
+// LobbyEntered implements ports.MeetingObserver
+func (s *recordingService) LobbyEntered(sessionId string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if session, ok := s.sessions[id]; ok {
-		session.Status = status
+
+	session, ok := s.sessions[sessionId]
+	if !ok || session.Status != domain.StatusJoining {
+		return
 	}
+	log.Printf("[Service] Session %s is waiting in the lobby", sessionId)
+	s.transitionLocked(session, domain.StatusInLobby, nil)
 }
 
-func (s *recordingService) updateError(id string, msg string) {
+// ArtifactCaptured implements ports.MeetingObserver
+func (s *recordingService) ArtifactCaptured(sessionId string, kind domain.ArtifactKind, path string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if session, ok := s.sessions[id]; ok {
-		session.Status = domain.StatusError
-		session.Error = msg
+
+	session, ok := s.sessions[sessionId]
+	if !ok {
+		return
+	}
+	session.AddArtifacts(domain.Artifacts{kind: path})
+	s.persistLocked(session)
+}
+
+// CaptionReceived implements ports.MeetingObserver. Captions are stored as
+// transcript segments relative to the recording start, like STT output.
+func (s *recordingService) CaptionReceived(sessionId string, caption domain.Caption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionId]
+	if !ok {
+		return
+	}
+
+	origin := caption.Start
+	if session.StartTime != nil {
+		origin = *session.StartTime
+	}
+	offset := func(t time.Time) time.Duration {
+		if d := t.Sub(origin); d > 0 {
+			return d
+		}
+		return 0
+	}
+
+	session.Captions = append(session.Captions, domain.TranscriptSegment{
+		Start:   offset(caption.Start),
+		End:     offset(caption.End),
+		Speaker: caption.Speaker,
+		Text:    caption.Text,
+	})
+	s.dirty[sessionId] = true
+}
+
+// ChatMessageReceived implements ports.MeetingObserver
+func (s *recordingService) ChatMessageReceived(sessionId string, message domain.ChatMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionId]
+	if !ok {
+		return
+	}
+	session.Chat = append(session.Chat, message)
+	s.dirty[sessionId] = true
+}
+
+// RosterSampled implements ports.MeetingObserver. Differences from the
+// previous sample become join/leave events on the session timeline.
+func (s *recordingService) RosterSampled(sessionId string, participants []string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionId]
+	if !ok {
+		return
+	}
+
+	if run := s.runs[sessionId]; run != nil {
+		// An empty roster only counts once someone has been listed, so a
+		// list that is still loading doesn't start the clock
+		if len(participants) > 0 {
+			run.rosterSeen = true
+			run.aloneSince = time.Time{}
+		} else if run.rosterSeen && run.aloneSince.IsZero() {
+			run.aloneSince = at
+		}
+	}
+
+	present := domain.PresentParticipants(session.Presence)
+	current := make(map[string]bool, len(participants))
+	changed := false
+	for _, name := range participants {
+		current[name] = true
+		if !present[name] {
+			session.Presence = append(session.Presence, domain.PresenceEvent{Participant: name, Type: domain.PresenceJoined, Time: at})
+			changed = true
+		}
+	}
+	var left []string
+	for name := range present {
+		if !current[name] {
+			left = append(left, name)
+		}
+	}
+	sort.Strings(left)
+	for _, name := range left {
+		session.Presence = append(session.Presence, domain.PresenceEvent{Participant: name, Type: domain.PresenceLeft, Time: at})
+		changed = true
+	}
+	if changed {
+		s.dirty[sessionId] = true
+	}
+}
+
+// watchRecordings publishes duration ticks for recording sessions, persists
+// what they captured since the last tick and stops the ones whose StopPolicy
+// says the meeting is over.
+func (s *recordingService) watchRecordings() {
+	ticker := time.NewTicker(durationTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.flushDirty()
+
+		now := time.Now()
+		due := make(map[string]domain.StopReason)
+
+		s.mu.RLock()
+		for id, session := range s.sessions {
+			if session.Status != domain.StatusRecording || session.StartTime == nil {
+				continue
+			}
+			s.events.Publish(domain.Event{
+				Type:      domain.EventDurationTick,
+				SessionID: id,
+				Status:    session.Status,
+				Duration:  now.Sub(*session.StartTime).Round(time.Second).String(),
+			})
+			if reason := s.policyReasonLocked(session, now); reason != "" {
+				due[id] = reason
+			}
+		}
+		s.mu.RUnlock()
+
+		for id, reason := range due {
+			log.Printf("[Service] Session %s hit its stop policy: %s", id, reason)
+			s.autoStop(id, reason)
+		}
+	}
+}
+
+// policyReasonLocked evaluates the session's StopPolicy. Caller must hold s.mu.
+func (s *recordingService) policyReasonLocked(session *domain.MeetingSession, now time.Time) domain.StopReason {
+	run := s.runs[session.ID]
+	if run == nil {
+		return ""
+	}
+	var alone, silent time.Duration
+	if !run.aloneSince.IsZero() {
+		alone = now.Sub(run.aloneSince)
+	}
+	if run.meter != nil {
+		silent = run.meter.SilentFor(now)
+	}
+	return session.StopPolicy.Triggered(now.Sub(*session.StartTime), alone, silent)
+}
+
+// transition applies a validated status change to a live session, then
+// persists and publishes it.
+func (s *recordingService) transition(id string, next domain.SessionStatus, mutate func(*domain.MeetingSession)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return domain.ErrSessionNotFound
+	}
+	return s.transitionLocked(session, next, mutate)
+}
+
+// transitionLocked is transition for callers already holding s.mu.
+func (s *recordingService) transitionLocked(session *domain.MeetingSession, next domain.SessionStatus, mutate func(*domain.MeetingSession)) error {
+	if err := session.Transition(next); err != nil {
+		log.Printf("[Service] Session %s: %v", session.ID, err)
+		return err
+	}
+	if mutate != nil {
+		mutate(session)
+	}
+	s.persistLocked(session)
+
+	event := domain.Event{Type: domain.EventStatusChanged, SessionID: session.ID, Status: next, Reason: string(session.StopReason)}
+	switch next {
+	case domain.StatusError:
+		event.Type = domain.EventError
+		event.Error = session.Error
+	case domain.StatusDenied:
+		event.Error = session.Error
+	}
+	s.events.Publish(event)
+	return nil
+}
+
+// flushDirty persists live sessions changed since the last flush. Captions,
+// chat and roster changes arrive continuously and are only written here, so
+// a session is saved at most once per tick however busy the meeting is.
+func (s *recordingService) flushDirty() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.dirty {
+		if session, ok := s.sessions[id]; ok {
+			s.persistLocked(session)
+		}
+		delete(s.dirty, id)
+	}
+}
+
+// persistLocked writes the session to the repository and drops it and its
+// run from the live maps, freeing its profile, once it has reached a terminal
+// status. Caller must hold s.mu.
+func (s *recordingService) persistLocked(session *domain.MeetingSession) {
+	if err := s.repo.Save(context.Background(), session); err != nil {
+		log.Printf("[Service] Failed to persist session %s: %v", session.ID, err)
+	}
+	delete(s.dirty, session.ID)
+	if !session.Status.IsActive() {
+		delete(s.sessions, session.ID)
+		delete(s.runs, session.ID)
+		if session.ProfileID != "" {
+			s.profiles.release(session.ProfileID, session.ID)
+		}
 	}
 }
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// stuckAutomator never gets the bot admitted: JoinMeeting waits until the
+// join is aborted.
+type stuckAutomator struct {
+	mu    sync.Mutex
+	stops int
+}
+
+func (a *stuckAutomator) JoinMeeting(ctx context.Context, session *domain.MeetingSession) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (a *stuckAutomator) StopMeeting(ctx context.Context, sessionId string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stops++
+	return nil
+}
+
+func (a *stuckAutomator) GetSnapshot(ctx context.Context, sessionId string) ([]byte, error) {
+	return nil, domain.ErrArtifactNotFound
+}
+
+func (a *stuckAutomator) GetMeetingStreams(ctx context.Context, sessionId string) (<-chan domain.VideoFrame, io.Reader, error) {
+	return nil, nil, ctx.Err()
+}
+
+func (a *stuckAutomator) SetObserver(observer ports.MeetingObserver) {}
+
+type nopMediaRecorder struct{}
+
+func (nopMediaRecorder) Start(ctx context.Context, sessionId string, videoFrames <-chan domain.VideoFrame, audioStream io.Reader) error {
+	return nil
+}
+
+func (nopMediaRecorder) Stop(ctx context.Context, sessionId string) (domain.Artifacts, error) {
+	return nil, nil
+}
+
+func (nopMediaRecorder) DeleteArtifacts(ctx context.Context, sessionId string) error { return nil }
+
+// newJoiningSession starts a session that stays joining until it is stopped.
+func newJoiningSession(t *testing.T) (*recordingService, *stuckAutomator, *domain.MeetingSession) {
+	t.Helper()
+	automator := &stuckAutomator{}
+	platforms := NewPlatformRegistry()
+	platforms.Register(domain.PlatformMeet, automator)
+	service := NewRecordingService(platforms, nopMediaRecorder{}, memory.NewSessionRepository(), NewEventBus(), NewProfileManager(memory.NewProfileRepository(), nil))
+
+	session, err := service.StartRecording(context.Background(), domain.StartRequest{
+		MeetingURL:      "https://meet.google.com/abc-defg-hij",
+		ParticipantName: "Minutes Bot",
+	})
+	if err != nil {
+		t.Fatalf("StartRecording: %v", err)
+	}
+	return service.(*recordingService), automator, session
+}
+
+func TestConcurrentStopsDuringJoin(t *testing.T) {
+	service, automator, session := newJoiningSession(t)
+
+	// Every stop must return, whichever of them ends up doing the teardown
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.StopRecording(context.Background(), session.ID); err != nil {
+				t.Errorf("StopRecording: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := service.GetSessionPlatform(context.Background(), session.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Status.IsActive() {
+			if got.Status != domain.StatusCancelled {
+				t.Errorf("status %s, want %s", got.Status, domain.StatusCancelled)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("session still %s", got.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	automator.mu.Lock()
+	defer automator.mu.Unlock()
+	if automator.stops != 1 {
+		t.Errorf("browser closed %d times, want once", automator.stops)
+	}
+}
+
+func TestAloneOnlyAfterSomeoneWasListed(t *testing.T) {
+	service, _, session := newJoiningSession(t)
+	defer service.StopRecording(context.Background(), session.ID)
+	start := time.Now()
+
+	aloneSince := func() time.Time {
+		service.mu.RLock()
+		defer service.mu.RUnlock()
+		return service.runs[session.ID].aloneSince
+	}
+
+	// The list is still loading
+	service.RosterSampled(session.ID, nil, start)
+	if got := aloneSince(); !got.IsZero() {
+		t.Errorf("alone since %s before anyone was listed", got)
+	}
+
+	service.RosterSampled(session.ID, []string{"Ada"}, start.Add(time.Minute))
+	service.RosterSampled(session.ID, nil, start.Add(2*time.Minute))
+	if got := aloneSince(); !got.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("alone since %s, want %s", got, start.Add(2*time.Minute))
+	}
+
+	service.RosterSampled(session.ID, []string{"Ada"}, start.Add(3*time.Minute))
+	if got := aloneSince(); !got.IsZero() {
+		t.Errorf("still alone since %s after someone rejoined", got)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// ruleBasedSummarizer extracts minutes with keyword heuristics. It needs no
+// model or network, so it is the fallback when no LLM is reachable. Quality
+// is modest: it only finds what was said explicitly.
+type ruleBasedSummarizer struct{}
+
+func NewRuleBasedSummarizer() ports.Summarizer {
+	return ruleBasedSummarizer{}
+}
+
+// summarySentences is how many leading sentences make up the summary
+const summarySentences = 3
+
+var (
+	decisionPattern = regexp.MustCompile(`(?i)\b(we (have )?(decided|agreed)|decision is|let's go with|we'll go with|agreed to|approved)\b`)
+	actionPattern   = regexp.MustCompile(`(?i)\b(action item|to-?do|follow up|(?:will|'ll) (send|prepare|review|check|update|write|draft|share|schedule|fix|look into)|needs? to|is going to)\b`)
+	// "<Name> will ..." or "<Name> is going to ..." at the start of a sentence
+	ownerPattern = regexp.MustCompile(`^([A-Z][a-z]+(?: [A-Z][a-z]+)?) (?:will|is going to|needs to|should)\b`)
+	duePattern   = regexp.MustCompile(`(?i)\b(?:by|before|due|until) ((?:next |this )?(?:monday|tuesday|wednesday|thursday|friday|saturday|sunday|week|month)|tomorrow|today|end of (?:the )?(?:day|week|month|quarter)|eod|eow|\d{4}-\d{2}-\d{2}|(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]* \d{1,2}(?:st|nd|rd|th)?)\b`)
+	sentenceEnd  = regexp.MustCompile(`([.!?])\s+`)
+)
+
+// notOwners start sentences like names do but say nothing about who owns the
+// action: "We will send the deck".
+var notOwners = map[string]bool{
+	"We": true, "You": true, "They": true, "He": true, "She": true, "It": true,
+	"Someone": true, "Somebody": true, "Everyone": true, "Everybody": true, "Nobody": true,
+}
+
+func (ruleBasedSummarizer) Name() string { return "rules" }
+
+func (ruleBasedSummarizer) Summarize(ctx context.Context, input domain.MinutesInput) (*domain.Minutes, error) {
+	if len(input.Transcript) == 0 && len(input.Chat) == 0 {
+		return nil, fmt.Errorf("transcript and chat are empty")
+	}
+
+	minutes := &domain.Minutes{
+		Decisions:     []string{},
+		ActionItems:   []domain.ActionItem{},
+		OpenQuestions: []string{},
+	}
+
+	classify := func(speaker, sentence string) {
+		switch {
+		case strings.HasSuffix(sentence, "?"):
+			minutes.OpenQuestions = append(minutes.OpenQuestions, attribute(speaker, sentence))
+		case decisionPattern.MatchString(sentence):
+			minutes.Decisions = append(minutes.Decisions, sentence)
+		case actionPattern.MatchString(sentence):
+			minutes.ActionItems = append(minutes.ActionItems, actionItem(speaker, sentence))
+		}
+	}
+
+	var all []string
+	for _, seg := range input.Transcript {
+		for _, sentence := range splitSentences(seg.Text) {
+			all = append(all, sentence)
+			classify(seg.Speaker, sentence)
+		}
+	}
+
+	// Chat feeds decisions and actions but only leads the summary when nothing was said
+	for _, msg := range input.Chat {
+		for _, sentence := range splitSentences(msg.Text) {
+			if len(input.Transcript) == 0 {
+				all = append(all, sentence)
+			}
+			classify(msg.Sender, sentence)
+		}
+	}
+
+	lead := all
+	if len(lead) > summarySentences {
+		lead = lead[:summarySentences]
+	}
+	summary := strings.Join(lead, " ")
+	if input.Duration != "" {
+		summary = fmt.Sprintf("Meeting of %s. %s", input.Duration, summary)
+	}
+	minutes.Summary = summary
+
+	return minutes, nil
+}
+
+func splitSentences(text string) []string {
+	text = sentenceEnd.ReplaceAllString(strings.TrimSpace(text), "$1\n")
+	var out []string
+	for _, s := range strings.Split(text, "\n") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func actionItem(speaker, sentence string) domain.ActionItem {
+	item := domain.ActionItem{Description: sentence}
+
+	if m := ownerPattern.FindStringSubmatch(sentence); m != nil && !notOwners[m[1]] {
+		item.Owner = m[1]
+	} else if strings.HasPrefix(sentence, "I ") || strings.HasPrefix(sentence, "I'll ") {
+		item.Owner = speaker
+	}
+	if m := duePattern.FindStringSubmatch(sentence); m != nil {
+		item.DueDate = m[1]
+	}
+	return item
+}
+
+func attribute(speaker, sentence string) string {
+	if speaker == "" {
+		return sentence
+	}
+	return speaker + ": " + sentence
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+func TestActionItemOwner(t *testing.T) {
+	tests := []struct {
+		sentence string
+		want     domain.ActionItem
+	}{
+		{"Bob will send the deck by Monday.", domain.ActionItem{Owner: "Bob", DueDate: "Monday"}},
+		{"Mary Jones is going to review the budget.", domain.ActionItem{Owner: "Mary Jones"}},
+		{"I'll check the logs by tomorrow.", domain.ActionItem{Owner: "Ann", DueDate: "tomorrow"}},
+		{"We will send the deck.", domain.ActionItem{}},
+		{"You need to update the roadmap.", domain.ActionItem{}},
+		{"They will fix the build by Friday.", domain.ActionItem{DueDate: "Friday"}},
+		{"Someone should follow up with legal.", domain.ActionItem{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sentence, func(t *testing.T) {
+			got := actionItem("Ann", tt.sentence)
+			if got.Owner != tt.want.Owner || got.DueDate != tt.want.DueDate {
+				t.Errorf("owner %q, due %q; want owner %q, due %q", got.Owner, got.DueDate, tt.want.Owner, tt.want.DueDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+// schedulerPoll caps how long the loop sleeps, so recordings that end on
+// their own are noticed even if their status event was dropped.
+const schedulerPoll = 30 * time.Second
+
+// Scheduler starts recordings at their booked time and stops them once the
+// maximum duration has passed. All state lives in the repository, so a
+// restart picks up where the previous process left off.
+type Scheduler struct {
+	repo      ports.ScheduleRepository
+	recorder  ports.RecordingService
+	platforms *PlatformRegistry
+	events    *EventBus
+	clock     ports.Clock
+	// Serializes schedule updates between ticks and API calls. It is never
+	// held across a recorder call, which can take minutes to stop a session.
+	mu   sync.Mutex
+	wake chan struct{}
+}
+
+func NewScheduler(repo ports.ScheduleRepository, recorder ports.RecordingService, platforms *PlatformRegistry, events *EventBus, clock ports.Clock) *Scheduler {
+	return &Scheduler{
+		repo:      repo,
+		recorder:  recorder,
+		platforms: platforms,
+		events:    events,
+		clock:     clock,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Run fires due schedules until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ch, cancel := s.events.Subscribe("")
+	defer cancel()
+
+	for {
+		wait := schedulerPoll
+		if next, ok := s.tick(ctx); ok {
+			if d := next.Sub(s.clock.Now()); d < wait {
+				wait = d
+			}
+		}
+		if wait < 0 {
+			wait = 0
+		}
+
+		timer := s.clock.After(wait)
+	sleep:
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer:
+				break sleep
+			case <-s.wake:
+				break sleep
+			case event := <-ch:
+				// A recording ending lets its schedule complete right away
+				if event.EndsSession() {
+					break sleep
+				}
+			}
+		}
+	}
+}
+
+func (s *Scheduler) CreateSchedule(ctx context.Context, req domain.ScheduleRequest) (*domain.Schedule, error) {
+	schedule, err := req.Build(uuid.New().String(), s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	// Rejected now rather than when the schedule fires
+	if _, _, err := s.platforms.Resolve(schedule.MeetingURL); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %w", err)
+	}
+	log.Printf("[Scheduler] Schedule %s: joining %s at %s", schedule.ID, schedule.MeetingURL, schedule.JoinAt.Format(time.RFC3339))
+	s.nudge()
+	return schedule, nil
+}
+
+func (s *Scheduler) GetSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	return s.repo.Get(ctx, scheduleId)
+}
+
+func (s *Scheduler) ListSchedules(ctx context.Context) ([]*domain.Schedule, error) {
+	return s.repo.List(ctx)
+}
+
+// CancelSchedule marks the schedule cancelled, then stops its recording if
+// it had started. A start already in flight sees the cancellation and stops
+// the session it created.
+func (s *Scheduler) CancelSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	s.mu.Lock()
+	schedule, err := s.repo.Get(ctx, scheduleId)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	if !schedule.Status.IsPending() {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", domain.ErrScheduleFinished, schedule.Status)
+	}
+	started := schedule.Status == domain.ScheduleStarted
+	schedule.Status = domain.ScheduleCancelled
+	err = s.repo.Save(ctx, schedule)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Scheduler] Schedule %s cancelled", scheduleId)
+
+	if started {
+		if _, err := s.recorder.StopRecording(ctx, schedule.SessionID); err != nil {
+			return nil, fmt.Errorf("failed to stop recording %s: %w", schedule.SessionID, err)
+		}
+	}
+	return schedule, nil
+}
+
+func (s *Scheduler) nudge() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// tick advances every pending schedule and returns the earliest moment one
+// of them needs attention again. What is due is worked out under s.mu; the
+// recorder is called after releasing it.
+func (s *Scheduler) tick(ctx context.Context) (time.Time, bool) {
+	var next time.Time
+	found := false
+	later := func(at time.Time) {
+		if !found || at.Before(next) {
+			next, found = at, true
+		}
+	}
+
+	s.mu.Lock()
+	schedules, err := s.repo.List(ctx)
+	if err != nil {
+		s.mu.Unlock()
+		log.Printf("[Scheduler] Failed to load schedules: %v", err)
+		return time.Time{}, false
+	}
+	now := s.clock.Now()
+	var due []*domain.Schedule
+	for _, schedule := range schedules {
+		switch {
+		case !schedule.Status.IsPending():
+		case schedule.Status == domain.ScheduleStarted:
+			due = append(due, schedule)
+		case !now.Before(schedule.Deadline()):
+			s.finish(ctx, schedule, domain.ScheduleMissed, "scheduler was not running during the meeting window")
+		case now.Before(schedule.JoinAt):
+			later(schedule.JoinAt)
+		default:
+			due = append(due, schedule)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, schedule := range due {
+		if at, ok := s.advance(ctx, schedule, now); ok {
+			later(at)
+		}
+	}
+	return next, found
+}
+
+// advance starts a due schedule or checks on a started one, and returns when
+// it is next due. It runs without s.mu, so results are stored with commit.
+func (s *Scheduler) advance(ctx context.Context, schedule *domain.Schedule, now time.Time) (time.Time, bool) {
+	switch schedule.Status {
+	case domain.ScheduleScheduled:
+		if !s.start(ctx, schedule) {
+			return time.Time{}, false
+		}
+		return schedule.Deadline(), true
+
+	case domain.ScheduleStarted:
+		session, err := s.recorder.GetSessionPlatform(ctx, schedule.SessionID)
+		if err != nil {
+			if errors.Is(err, domain.ErrSessionNotFound) {
+				s.commitFinish(ctx, schedule, domain.ScheduleCompleted, "session was deleted")
+				return time.Time{}, false
+			}
+			log.Printf("[Scheduler] Failed to load session %s: %v", schedule.SessionID, err)
+			return schedule.Deadline(), true
+		}
+		if !session.Status.IsActive() {
+			s.commitFinish(ctx, schedule, domain.ScheduleCompleted, session.Error)
+			return time.Time{}, false
+		}
+		if now.Before(schedule.Deadline()) {
+			return schedule.Deadline(), true
+		}
+		log.Printf("[Scheduler] Schedule %s reached its maximum duration, stopping session %s", schedule.ID, schedule.SessionID)
+		if _, err := s.recorder.StopRecording(ctx, schedule.SessionID); err != nil {
+			log.Printf("[Scheduler] Failed to stop session %s: %v", schedule.SessionID, err)
+			return now.Add(schedulerPoll), true
+		}
+		s.commitFinish(ctx, schedule, domain.ScheduleCompleted, "")
+	}
+	return time.Time{}, false
+}
+
+// start begins the schedule's recording and reports whether it is now started.
+func (s *Scheduler) start(ctx context.Context, schedule *domain.Schedule) bool {
+	log.Printf("[Scheduler] Starting schedule %s (%s)", schedule.ID, schedule.MeetingURL)
+	session, err := s.recorder.StartRecording(ctx, schedule.StartRequest())
+	if err != nil {
+		log.Printf("[Scheduler] Schedule %s failed to start: %v", schedule.ID, err)
+		s.commitFinish(ctx, schedule, domain.ScheduleFailed, err.Error())
+		return false
+	}
+	started := s.commit(ctx, schedule, func(schedule *domain.Schedule) {
+		schedule.Status = domain.ScheduleStarted
+		schedule.SessionID = session.ID
+	})
+	if !started {
+		log.Printf("[Scheduler] Schedule %s was cancelled while starting, stopping session %s", schedule.ID, session.ID)
+		if _, err := s.recorder.StopRecording(ctx, session.ID); err != nil {
+			log.Printf("[Scheduler] Failed to stop session %s: %v", session.ID, err)
+		}
+	}
+	return started
+}
+
+// commit applies mutate to the stored schedule if it is still as tick found
+// it; a cancel may have changed it while the recorder was being called.
+func (s *Scheduler) commit(ctx context.Context, schedule *domain.Schedule, mutate func(*domain.Schedule)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.repo.Get(ctx, schedule.ID)
+	if err != nil {
+		log.Printf("[Scheduler] Failed to load schedule %s: %v", schedule.ID, err)
+		return false
+	}
+	if current.Status != schedule.Status || current.SessionID != schedule.SessionID {
+		return false
+	}
+	mutate(current)
+	if err := s.repo.Save(ctx, current); err != nil {
+		log.Printf("[Scheduler] Failed to save schedule %s: %v", schedule.ID, err)
+	}
+	*schedule = *current
+	return true
+}
+
+func (s *Scheduler) commitFinish(ctx context.Context, schedule *domain.Schedule, status domain.ScheduleStatus, reason string) {
+	s.commit(ctx, schedule, func(schedule *domain.Schedule) {
+		schedule.Status = status
+		schedule.Error = reason
+	})
+}
+
+// finish moves a schedule to a final status. Caller must hold s.mu.
+func (s *Scheduler) finish(ctx context.Context, schedule *domain.Schedule, status domain.ScheduleStatus, reason string) {
+	schedule.Status = status
+	schedule.Error = reason
+	if err := s.repo.Save(ctx, schedule); err != nil {
+		log.Printf("[Scheduler] Failed to save schedule %s: %v", schedule.ID, err)
+	}
+}
@@ -0,0 +1,511 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+// fakeClock only moves when the test advances it.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []clockWaiter
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, clockWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Set moves the clock to t, firing every timer that has come due.
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(t) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- t
+	}
+	c.waiters = pending
+}
+
+// fakeRecorder stands in for the recording service, keeping just enough
+// session state for the scheduler.
+type fakeRecorder struct {
+	mu       sync.Mutex
+	sessions map[string]*domain.MeetingSession
+	started  []domain.StartRequest
+	stopped  []string
+	startErr error
+
+	// Test hooks, called without r.mu held
+	onStart func()
+	onStop  func()
+}
+
+var _ ports.RecordingService = (*fakeRecorder)(nil)
+
+func newFakeRecorder() *fakeRecorder {
+	return &fakeRecorder{sessions: make(map[string]*domain.MeetingSession)}
+}
+
+func (r *fakeRecorder) StartRecording(ctx context.Context, req domain.StartRequest) (*domain.MeetingSession, error) {
+	if r.onStart != nil {
+		r.onStart()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.startErr != nil {
+		return nil, r.startErr
+	}
+	r.started = append(r.started, req)
+	session := &domain.MeetingSession{ID: fmt.Sprintf("session-%d", len(r.started)), MeetingURL: req.MeetingURL, Status: domain.StatusJoining}
+	r.sessions[session.ID] = session
+	out := *session
+	return &out, nil
+}
+
+func (r *fakeRecorder) StopRecording(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+	if r.onStop != nil {
+		r.onStop()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[sessionId]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	r.stopped = append(r.stopped, sessionId)
+	session.Status = domain.StatusStopped
+	out := *session
+	return &out, nil
+}
+
+func (r *fakeRecorder) GetSessionPlatform(ctx context.Context, sessionId string) (*domain.MeetingSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[sessionId]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	out := *session
+	return &out, nil
+}
+
+func (r *fakeRecorder) setSession(session *domain.MeetingSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = session
+}
+
+func (r *fakeRecorder) stoppedSessions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.stopped...)
+}
+
+func (r *fakeRecorder) startCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.started)
+}
+
+func (r *fakeRecorder) ListSessions(ctx context.Context, filter domain.SessionFilter) (*domain.SessionPage, error) {
+	return &domain.SessionPage{}, nil
+}
+
+func (r *fakeRecorder) GetArtifactPath(ctx context.Context, sessionId string, kind domain.ArtifactKind) (string, error) {
+	return "", domain.ErrArtifactNotFound
+}
+
+func (r *fakeRecorder) DeleteSession(ctx context.Context, sessionId string) error { return nil }
+
+func (r *fakeRecorder) SubscribeEvents(ctx context.Context, sessionId string) (<-chan domain.Event, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *fakeRecorder) Shutdown(ctx context.Context) error { return nil }
+
+// The meeting in every test starts at 10:00, the bot joins 2 minutes early
+// and the recording may run for an hour.
+var meetingStart = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+type schedulerFixture struct {
+	scheduler *Scheduler
+	repo      ports.ScheduleRepository
+	recorder  *fakeRecorder
+	clock     *fakeClock
+}
+
+func newSchedulerFixture(t *testing.T, now time.Time) *schedulerFixture {
+	t.Helper()
+	f := &schedulerFixture{
+		repo:     memory.NewScheduleRepository(),
+		recorder: newFakeRecorder(),
+		clock:    newFakeClock(now),
+	}
+	platforms := NewPlatformRegistry()
+	platforms.Register(domain.PlatformMeet, &stuckAutomator{})
+	f.scheduler = NewScheduler(f.repo, f.recorder, platforms, NewEventBus(), f.clock)
+	return f
+}
+
+func (f *schedulerFixture) create(t *testing.T) *domain.Schedule {
+	t.Helper()
+	return f.createAt(t, meetingStart)
+}
+
+func (f *schedulerFixture) createAt(t *testing.T, start time.Time) *domain.Schedule {
+	t.Helper()
+	schedule, err := f.scheduler.CreateSchedule(context.Background(), domain.ScheduleRequest{
+		MeetingURL:         "https://meet.google.com/abc-defg-hij",
+		ParticipantName:    "Minutes Bot",
+		StartTime:          start.Format(time.RFC3339),
+		JoinLeadMinutes:    2,
+		MaxDurationMinutes: 60,
+	})
+	if err != nil {
+		t.Fatalf("CreateSchedule: %v", err)
+	}
+	return schedule
+}
+
+func (f *schedulerFixture) get(t *testing.T, id string) *domain.Schedule {
+	t.Helper()
+	schedule, err := f.repo.Get(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+// tickAt runs one scheduler pass at t and returns when it wants to run next.
+func (f *schedulerFixture) tickAt(t time.Time) (time.Time, bool) {
+	f.clock.Set(t)
+	return f.scheduler.tick(context.Background())
+}
+
+func TestSchedulerWaitsForJoinTime(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+
+	next, ok := f.tickAt(meetingStart.Add(-3 * time.Minute))
+	if !ok || !next.Equal(schedule.JoinAt) {
+		t.Errorf("next tick = %s, %v; want the join time %s", next, ok, schedule.JoinAt)
+	}
+	if n := f.recorder.startCount(); n != 0 {
+		t.Errorf("started %d recordings before the join time", n)
+	}
+}
+
+func TestSchedulerStartsOnTime(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.scheduler.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Run sleeps on the clock until the join time
+	waitFor(t, func() bool { return f.clockWaiting() })
+	f.clock.Set(schedule.JoinAt)
+	waitFor(t, func() bool { return f.get(t, schedule.ID).Status == domain.ScheduleStarted })
+
+	got := f.get(t, schedule.ID)
+	if got.SessionID == "" {
+		t.Error("started schedule has no session")
+	}
+	if f.recorder.started[0].MeetingURL != schedule.MeetingURL {
+		t.Errorf("started %q, want %q", f.recorder.started[0].MeetingURL, schedule.MeetingURL)
+	}
+}
+
+func TestSchedulerStartsLateInsideWindow(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+
+	// The service was down at the join time but the meeting is still on
+	next, ok := f.tickAt(meetingStart.Add(45 * time.Minute))
+	got := f.get(t, schedule.ID)
+	if got.Status != domain.ScheduleStarted || f.recorder.startCount() != 1 {
+		t.Fatalf("status %s after %d starts, want started once", got.Status, f.recorder.startCount())
+	}
+	if !ok || !next.Equal(schedule.Deadline()) {
+		t.Errorf("next tick = %s, %v; want the deadline %s", next, ok, schedule.Deadline())
+	}
+}
+
+func TestSchedulerMissesAfterDeadline(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+
+	if _, ok := f.tickAt(schedule.Deadline()); ok {
+		t.Error("a missed schedule asked to be ticked again")
+	}
+	got := f.get(t, schedule.ID)
+	if got.Status != domain.ScheduleMissed || got.Error == "" {
+		t.Errorf("status %s (error %q), want missed with a reason", got.Status, got.Error)
+	}
+	if n := f.recorder.startCount(); n != 0 {
+		t.Errorf("started %d recordings for a missed schedule", n)
+	}
+}
+
+func TestSchedulerStopsAtDeadline(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+	f.tickAt(schedule.JoinAt)
+	sessionId := f.get(t, schedule.ID).SessionID
+
+	f.tickAt(schedule.Deadline().Add(-time.Second))
+	if len(f.recorder.stopped) != 0 {
+		t.Fatalf("stopped %v before the deadline", f.recorder.stopped)
+	}
+
+	if _, ok := f.tickAt(schedule.Deadline()); ok {
+		t.Error("a completed schedule asked to be ticked again")
+	}
+	if len(f.recorder.stopped) != 1 || f.recorder.stopped[0] != sessionId {
+		t.Errorf("stopped %v, want [%s]", f.recorder.stopped, sessionId)
+	}
+	if got := f.get(t, schedule.ID); got.Status != domain.ScheduleCompleted {
+		t.Errorf("status %s, want completed", got.Status)
+	}
+}
+
+func TestSchedulerCompletesWhenRecordingEnds(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+	f.tickAt(schedule.JoinAt)
+	sessionId := f.get(t, schedule.ID).SessionID
+
+	f.recorder.setSession(&domain.MeetingSession{ID: sessionId, Status: domain.StatusStopped})
+	f.tickAt(meetingStart.Add(20 * time.Minute))
+
+	if got := f.get(t, schedule.ID); got.Status != domain.ScheduleCompleted {
+		t.Errorf("status %s, want completed", got.Status)
+	}
+	if len(f.recorder.stopped) != 0 {
+		t.Errorf("stopped %v, want no stop for a finished session", f.recorder.stopped)
+	}
+}
+
+func TestSchedulerFailsWhenStartFails(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+	f.recorder.startErr = domain.ErrInvalidRequest
+
+	f.tickAt(schedule.JoinAt)
+	got := f.get(t, schedule.ID)
+	if got.Status != domain.ScheduleFailed || got.Error == "" {
+		t.Errorf("status %s (error %q), want failed with the start error", got.Status, got.Error)
+	}
+}
+
+// A restarted process finds schedules still marked started. What happened to
+// their session decides whether they are still running.
+func TestSchedulerResumesStartedSchedules(t *testing.T) {
+	tests := []struct {
+		name       string
+		session    *domain.MeetingSession // nil: deleted
+		at         time.Time
+		wantStatus domain.ScheduleStatus
+		wantStop   bool
+	}{
+		{
+			name:       "still recording",
+			session:    &domain.MeetingSession{ID: "session-1", Status: domain.StatusRecording},
+			at:         meetingStart.Add(30 * time.Minute),
+			wantStatus: domain.ScheduleStarted,
+		},
+		{
+			name:       "still recording past the deadline",
+			session:    &domain.MeetingSession{ID: "session-1", Status: domain.StatusRecording},
+			at:         meetingStart.Add(2 * time.Hour),
+			wantStatus: domain.ScheduleCompleted,
+			wantStop:   true,
+		},
+		{
+			name:       "interrupted by the restart",
+			session:    &domain.MeetingSession{ID: "session-1", Status: domain.StatusInterrupted, Error: "service restarted while session was recording"},
+			at:         meetingStart.Add(30 * time.Minute),
+			wantStatus: domain.ScheduleCompleted,
+		},
+		{
+			name:       "session deleted",
+			at:         meetingStart.Add(30 * time.Minute),
+			wantStatus: domain.ScheduleCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSchedulerFixture(t, tt.at)
+			schedule := &domain.Schedule{
+				ID:                 "schedule-1",
+				MeetingURL:         "https://meet.google.com/abc-defg-hij",
+				StartAt:            meetingStart,
+				JoinAt:             meetingStart.Add(-2 * time.Minute),
+				MaxDurationMinutes: 60,
+				Status:             domain.ScheduleStarted,
+				SessionID:          "session-1",
+				CreatedAt:          meetingStart.Add(-time.Hour),
+			}
+			if err := f.repo.Save(context.Background(), schedule); err != nil {
+				t.Fatal(err)
+			}
+			if tt.session != nil {
+				f.recorder.setSession(tt.session)
+			}
+
+			next, ok := f.tickAt(tt.at)
+
+			got := f.get(t, schedule.ID)
+			if got.Status != tt.wantStatus {
+				t.Errorf("status %s, want %s", got.Status, tt.wantStatus)
+			}
+			if stopped := len(f.recorder.stopped) == 1; stopped != tt.wantStop {
+				t.Errorf("stopped %v, want a stop: %v", f.recorder.stopped, tt.wantStop)
+			}
+			if n := f.recorder.startCount(); n != 0 {
+				t.Errorf("started %d new recordings for a resumed schedule", n)
+			}
+			if tt.wantStatus == domain.ScheduleStarted && (!ok || !next.Equal(schedule.Deadline())) {
+				t.Errorf("next tick = %s, %v; want the deadline %s", next, ok, schedule.Deadline())
+			}
+		})
+	}
+}
+
+func TestSchedulerRejectsUnsupportedMeeting(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	for _, url := range []string{"https://example.com/meeting/42", "https://zoom.us/j/123"} {
+		_, err := f.scheduler.CreateSchedule(context.Background(), domain.ScheduleRequest{
+			MeetingURL: url,
+			StartTime:  meetingStart.Format(time.RFC3339),
+		})
+		var unsupported *domain.UnsupportedMeetingError
+		if !errors.As(err, &unsupported) {
+			t.Errorf("CreateSchedule(%s) = %v, want an unsupported meeting error", url, err)
+		}
+	}
+}
+
+// A slow stop must not hold up the API or other schedules.
+func TestSchedulerStopsWithoutHoldingLock(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	first := f.create(t)
+	f.tickAt(first.JoinAt)
+
+	release := make(chan struct{})
+	stopping := make(chan struct{})
+	f.recorder.onStop = func() {
+		close(stopping)
+		<-release
+	}
+	done := make(chan struct{})
+	go func() {
+		f.tickAt(first.Deadline())
+		close(done)
+	}()
+	<-stopping
+
+	second := f.createAt(t, meetingStart.Add(24*time.Hour))
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := f.scheduler.CancelSchedule(context.Background(), second.ID)
+		cancelled <- err
+	}()
+	select {
+	case err := <-cancelled:
+		if err != nil {
+			t.Errorf("CancelSchedule: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("CancelSchedule waited for a recording to stop")
+	}
+
+	close(release)
+	<-done
+	if got := f.get(t, first.ID); got.Status != domain.ScheduleCompleted {
+		t.Errorf("status %s, want completed", got.Status)
+	}
+}
+
+func TestSchedulerCancelledWhileStarting(t *testing.T) {
+	f := newSchedulerFixture(t, meetingStart.Add(-time.Hour))
+	schedule := f.create(t)
+	f.recorder.onStart = func() {
+		if _, err := f.scheduler.CancelSchedule(context.Background(), schedule.ID); err != nil {
+			t.Errorf("CancelSchedule: %v", err)
+		}
+	}
+
+	if _, ok := f.tickAt(schedule.JoinAt); ok {
+		t.Error("a cancelled schedule asked to be ticked again")
+	}
+	if got := f.get(t, schedule.ID); got.Status != domain.ScheduleCancelled || got.SessionID != "" {
+		t.Errorf("status %s with session %q, want cancelled without one", got.Status, got.SessionID)
+	}
+	if stopped := f.recorder.stoppedSessions(); len(stopped) != 1 || stopped[0] != "session-1" {
+		t.Errorf("stopped %v, want the session started for the cancelled schedule", stopped)
+	}
+}
+
+func (f *schedulerFixture) clockWaiting() bool {
+	f.clock.mu.Lock()
+	defer f.clock.mu.Unlock()
+	return len(f.clock.waiters) > 0
+}
+
+// waitFor polls cond for up to a second, for work done by a Run goroutine.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+)
+
+// pageCursor marks the last session of a page by its sort key and ID,
+// so pagination stays stable while new sessions are being created.
+type pageCursor struct {
+	Key int64  `json:"k"`
+	ID  string `json:"id"`
+}
+
+func encodeCursor(c pageCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidFilter)
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidFilter)
+	}
+	return c, nil
+}
+
+// sortNanos turns a sort key into a cursor key. An unset time sorts before
+// every real one; UnixNano is undefined for it.
+func sortNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return math.MinInt64
+	}
+	return t.UnixNano()
+}
+
+// paginate filters, sorts and slices sessions according to filter.
+// The filter must already be normalized.
+func paginate(sessions []*domain.MeetingSession, filter domain.SessionFilter) (*domain.SessionPage, error) {
+	matched := make([]*domain.MeetingSession, 0, len(sessions))
+	for _, s := range sessions {
+		if filter.Matches(s) {
+			matched = append(matched, s)
+		}
+	}
+
+	key := func(s *domain.MeetingSession) pageCursor {
+		return pageCursor{Key: sortNanos(filter.SortKey(s)), ID: s.ID}
+	}
+	// less orders by (sort key, id) in the requested direction
+	less := func(a, b pageCursor) bool {
+		if filter.Descending {
+			a, b = b, a
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.ID < b.ID
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return less(key(matched[i]), key(matched[j]))
+	})
+
+	start := 0
+	if filter.Cursor != "" {
+		after, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		start = sort.Search(len(matched), func(i int) bool {
+			return less(after, key(matched[i]))
+		})
+	}
+
+	end := start + filter.Limit
+	page := &domain.SessionPage{Sessions: []*domain.MeetingSession{}}
+	if end < len(matched) {
+		page.NextCursor = encodeCursor(key(matched[end-1]))
+	} else {
+		end = len(matched)
+	}
+	page.Sessions = append(page.Sessions, matched[start:end]...)
+	return page, nil
+}
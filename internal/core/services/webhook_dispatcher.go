@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookPollInterval = 2 * time.Second
+	webhookBatchSize    = 20
+	webhookBaseBackoff  = 5 * time.Second
+	webhookMaxBackoff   = time.Hour
+	webhookMaxAttempts  = 12 // ~2.4h of retries before giving up
+	// Dead deliveries are kept this long after they were created, then pruned
+	webhookDeadRetention = 7 * 24 * time.Hour
+	webhookPruneInterval = time.Hour
+)
+
+// WebhookDispatcher turns session events into webhook deliveries, stores them
+// in the outbox and delivers them with exponential backoff. Deliveries are
+// enqueued while the event is published, so none is lost to a slow consumer,
+// and because the outbox is persistent, pending ones resume after a restart.
+type WebhookDispatcher struct {
+	outbox     ports.WebhookOutbox
+	sender     ports.WebhookSender
+	repo       ports.SessionRepository
+	globalURLs []string
+	wake       chan struct{}
+}
+
+func NewWebhookDispatcher(outbox ports.WebhookOutbox, sender ports.WebhookSender, repo ports.SessionRepository, events *EventBus, globalURLs []string) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		outbox:     outbox,
+		sender:     sender,
+		repo:       repo,
+		globalURLs: globalURLs,
+		wake:       make(chan struct{}, 1),
+	}
+	events.Handle(func(event domain.Event) {
+		d.enqueue(context.Background(), event)
+	})
+	return d
+}
+
+// Run drives deliveries until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	d.deliverLoop(ctx)
+}
+
+// webhookType maps a session event to the webhook it triggers, if any.
+func webhookType(event domain.Event) (domain.WebhookEventType, bool) {
+	switch event.Type {
+	case domain.EventStatusChanged:
+		switch event.Status {
+		case domain.StatusRecording:
+			return domain.WebhookRecordingStarted, true
+		case domain.StatusStopped:
+			return domain.WebhookRecordingStopped, true
+		case domain.StatusCancelled:
+			return domain.WebhookRecordingCancelled, true
+		case domain.StatusDenied:
+			return domain.WebhookRecordingDenied, true
+		}
+	case domain.EventError:
+		return domain.WebhookRecordingFailed, true
+	case domain.EventAutoStopDetected:
+		return domain.WebhookRecordingAutoStopped, true
+	}
+	return "", false
+}
+
+func (d *WebhookDispatcher) enqueue(ctx context.Context, event domain.Event) {
+	eventType, ok := webhookType(event)
+	if !ok {
+		return
+	}
+
+	session, err := d.repo.Get(ctx, event.SessionID)
+	if err != nil {
+		log.Printf("[Webhook] Skipping %s for session %s: %v", eventType, event.SessionID, err)
+		return
+	}
+
+	urls := append(append([]string{}, d.globalURLs...), session.CallbackURLs...)
+	if len(urls) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(domain.WebhookPayload{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Time:      event.Time,
+		SessionID: event.SessionID,
+		Reason:    event.Reason,
+		Session:   session,
+	})
+	if err != nil {
+		log.Printf("[Webhook] Failed to encode %s payload: %v", eventType, err)
+		return
+	}
+
+	now := time.Now()
+	for _, url := range urls {
+		delivery := &domain.WebhookDelivery{
+			ID:          uuid.New().String(),
+			URL:         url,
+			EventType:   eventType,
+			Payload:     payload,
+			NextAttempt: now,
+			CreatedAt:   now,
+		}
+		if err := d.outbox.Enqueue(ctx, delivery); err != nil {
+			log.Printf("[Webhook] Failed to enqueue %s for %s: %v", eventType, url, err)
+		}
+	}
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *WebhookDispatcher) deliverLoop(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+	prune := time.NewTicker(webhookPruneInterval)
+	defer prune.Stop()
+
+	d.pruneDead(ctx)
+	for {
+		d.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		case <-prune.C:
+			d.pruneDead(ctx)
+		}
+	}
+}
+
+// pruneDead drops dead deliveries past their retention, so they stop
+// weighing on every poll of the outbox.
+func (d *WebhookDispatcher) pruneDead(ctx context.Context) {
+	n, err := d.outbox.PruneDead(ctx, time.Now().Add(-webhookDeadRetention))
+	if err != nil {
+		log.Printf("[Webhook] Failed to prune dead deliveries: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("[Webhook] Pruned %d dead deliveries", n)
+	}
+}
+
+func (d *WebhookDispatcher) deliverDue(ctx context.Context) {
+	due, err := d.outbox.Due(ctx, time.Now(), webhookBatchSize)
+	if err != nil {
+		log.Printf("[Webhook] Failed to read outbox: %v", err)
+		return
+	}
+
+	for _, delivery := range due {
+		err := d.sender.Send(ctx, delivery)
+		if err == nil {
+			if err := d.outbox.Delete(ctx, delivery.ID); err != nil {
+				log.Printf("[Webhook] Failed to remove delivered %s: %v", delivery.ID, err)
+			}
+			continue
+		}
+
+		delivery.Attempts++
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Dead = true
+			log.Printf("[Webhook] Giving up on %s to %s after %d attempts: %v", delivery.EventType, delivery.URL, delivery.Attempts, err)
+		} else {
+			delivery.NextAttempt = time.Now().Add(webhookBackoff(delivery.Attempts))
+			log.Printf("[Webhook] Delivery of %s to %s failed (attempt %d), retrying at %s: %v",
+				delivery.EventType, delivery.URL, delivery.Attempts, delivery.NextAttempt.Format(time.RFC3339), err)
+		}
+		if err := d.outbox.Update(ctx, delivery); err != nil {
+			log.Printf("[Webhook] Failed to update %s: %v", delivery.ID, err)
+		}
+	}
+}
+
+// webhookBackoff doubles the delay per failed attempt, capped at webhookMaxBackoff.
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		backoff = webhookMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-meeting-recorder/internal/adapters/secondary/memory"
+	"go-meeting-recorder/internal/adapters/secondary/webhook"
+	"go-meeting-recorder/internal/core/domain"
+	"go-meeting-recorder/internal/core/ports"
+)
+
+const testWebhookSecret = "test-secret"
+
+// webhookReceiver records what it is sent and answers with status.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, receivedWebhook{header: r.Header.Clone(), body: body})
+	w.WriteHeader(rc.status)
+}
+
+func (rc *webhookReceiver) setStatus(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.status = status
+}
+
+func (rc *webhookReceiver) received() []receivedWebhook {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]receivedWebhook(nil), rc.requests...)
+}
+
+type webhookFixture struct {
+	dispatcher *WebhookDispatcher
+	outbox     ports.WebhookOutbox
+	events     *EventBus
+	receiver   *webhookReceiver
+	url        string
+}
+
+func newWebhookFixture(t *testing.T, secret string) *webhookFixture {
+	t.Helper()
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	repo := memory.NewSessionRepository()
+	session := &domain.MeetingSession{
+		ID:           "session-1",
+		MeetingURL:   "https://meet.google.com/abc-defg-hij",
+		Platform:     domain.PlatformMeet,
+		CallbackURLs: []string{server.URL},
+		Status:       domain.StatusRecording,
+		CreatedAt:    time.Now(),
+	}
+	if err := repo.Save(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := memory.NewWebhookOutbox()
+	events := NewEventBus()
+	return &webhookFixture{
+		dispatcher: NewWebhookDispatcher(outbox, webhook.NewHTTPSender(secret), repo, events, nil),
+		outbox:     outbox,
+		events:     events,
+		receiver:   receiver,
+		url:        server.URL,
+	}
+}
+
+// pending returns every delivery still in the outbox, due or not.
+func (f *webhookFixture) pending(t *testing.T) []*domain.WebhookDelivery {
+	t.Helper()
+	due, err := f.outbox.Due(context.Background(), time.Now().Add(365*24*time.Hour), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return due
+}
+
+// makeDue pulls every pending delivery's next attempt to now.
+func (f *webhookFixture) makeDue(t *testing.T) {
+	t.Helper()
+	for _, delivery := range f.pending(t) {
+		delivery.NextAttempt = time.Now().Add(-time.Second)
+		if err := f.outbox.Update(context.Background(), delivery); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWebhookDispatcherSignsPayload(t *testing.T) {
+	f := newWebhookFixture(t, testWebhookSecret)
+	f.events.Publish(domain.Event{Type: domain.EventStatusChanged, SessionID: "session-1", Status: domain.StatusRecording})
+	f.dispatcher.deliverDue(context.Background())
+
+	got := f.receiver.received()
+	if len(got) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(got))
+	}
+	req := got[0]
+	if ev := req.header.Get("X-Webhook-Event"); ev != string(domain.WebhookRecordingStarted) {
+		t.Errorf("X-Webhook-Event = %q, want %q", ev, domain.WebhookRecordingStarted)
+	}
+
+	// Verify the way a receiver would: v1 = HMAC-SHA256(secret, "<t>.<body>")
+	var ts, sig string
+	for _, part := range strings.Split(req.header.Get("X-Webhook-Signature"), ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(req.body)
+	if want := hex.EncodeToString(mac.Sum(nil)); ts == "" || !hmac.Equal([]byte(sig), []byte(want)) {
+		t.Errorf("signature %q does not verify (t=%q, want v1=%s)", req.header.Get("X-Webhook-Signature"), ts, want)
+	}
+
+	var payload domain.WebhookPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload.Type != domain.WebhookRecordingStarted || payload.SessionID != "session-1" || payload.Session == nil {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if n := len(f.pending(t)); n != 0 {
+		t.Errorf("%d deliveries left after a 2xx, want 0", n)
+	}
+}
+
+func TestWebhookDispatcherUnsignedWithoutSecret(t *testing.T) {
+	f := newWebhookFixture(t, "")
+	f.events.Publish(domain.Event{Type: domain.EventStatusChanged, SessionID: "session-1", Status: domain.StatusStopped})
+	f.dispatcher.deliverDue(context.Background())
+
+	got := f.receiver.received()
+	if len(got) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(got))
+	}
+	if sig := got[0].header.Get("X-Webhook-Signature"); sig != "" {
+		t.Errorf("X-Webhook-Signature = %q, want none", sig)
+	}
+}
+
+func TestWebhookDispatcherRetriesWithBackoff(t *testing.T) {
+	f := newWebhookFixture(t, testWebhookSecret)
+	f.receiver.setStatus(http.StatusServiceUnavailable)
+	f.events.Publish(domain.Event{Type: domain.EventStatusChanged, SessionID: "session-1", Status: domain.StatusStopped})
+
+	before := time.Now()
+	f.dispatcher.deliverDue(context.Background())
+	pending := f.pending(t)
+	if len(pending) != 1 {
+		t.Fatalf("%d deliveries pending after a failure, want 1", len(pending))
+	}
+	delivery := pending[0]
+	if delivery.Attempts != 1 || delivery.Dead || delivery.LastError == "" {
+		t.Errorf("after one failure got attempts=%d dead=%v lastError=%q", delivery.Attempts, delivery.Dead, delivery.LastError)
+	}
+	if delivery.NextAttempt.Before(before.Add(webhookBaseBackoff)) {
+		t.Errorf("next attempt %s is sooner than the base backoff", delivery.NextAttempt)
+	}
+
+	// Not due yet: no second request
+	f.dispatcher.deliverDue(context.Background())
+	if n := len(f.receiver.received()); n != 1 {
+		t.Fatalf("receiver got %d requests before the backoff elapsed, want 1", n)
+	}
+
+	f.receiver.setStatus(http.StatusOK)
+	f.makeDue(t)
+	f.dispatcher.deliverDue(context.Background())
+	got := f.receiver.received()
+	if len(got) != 2 {
+		t.Fatalf("receiver got %d requests, want 2", len(got))
+	}
+	if a, b := got[0].header.Get("X-Webhook-Delivery"), got[1].header.Get("X-Webhook-Delivery"); a != b {
+		t.Errorf("retry changed the delivery ID from %s to %s", a, b)
+	}
+	if n := len(f.pending(t)); n != 0 {
+		t.Errorf("%d deliveries left after the retry succeeded, want 0", n)
+	}
+}
+
+func TestWebhookDispatcherDeadLetters(t *testing.T) {
+	f := newWebhookFixture(t, testWebhookSecret)
+	f.receiver.setStatus(http.StatusInternalServerError)
+	f.events.Publish(domain.Event{Type: domain.EventError, SessionID: "session-1", Error: "boom"})
+
+	for i := 0; i < webhookMaxAttempts; i++ {
+		f.makeDue(t)
+		f.dispatcher.deliverDue(context.Background())
+	}
+	if n := len(f.receiver.received()); n != webhookMaxAttempts {
+		t.Fatalf("receiver got %d attempts, want %d", n, webhookMaxAttempts)
+	}
+	if n := len(f.pending(t)); n != 0 {
+		t.Fatalf("%d deliveries still live after %d attempts, want 0", n, webhookMaxAttempts)
+	}
+
+	// Dead deliveries are never retried
+	f.dispatcher.deliverDue(context.Background())
+	if n := len(f.receiver.received()); n != webhookMaxAttempts {
+		t.Errorf("dead delivery was retried")
+	}
+
+	// ...and are pruned once past their retention
+	if n, err := f.outbox.PruneDead(context.Background(), time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("PruneDead before retention = %d, %v; want 0", n, err)
+	}
+	if n, err := f.outbox.PruneDead(context.Background(), time.Now().Add(time.Hour)); err != nil || n != 1 {
+		t.Errorf("PruneDead after retention = %d, %v; want 1", n, err)
+	}
+}
+
+func TestWebhookDispatcherEnqueuesEveryEvent(t *testing.T) {
+	f := newWebhookFixture(t, testWebhookSecret)
+
+	// Far more than a subscriber's buffer; none may be dropped
+	const n = 5 * subscriberBuffer
+	for i := 0; i < n; i++ {
+		f.events.Publish(domain.Event{Type: domain.EventError, SessionID: "session-1", Error: "boom"})
+	}
+	if got := len(f.pending(t)); got != n {
+		t.Errorf("outbox has %d deliveries, want %d", got, n)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	var total time.Duration
+	for attempt := 1; attempt < webhookMaxAttempts; attempt++ {
+		b := webhookBackoff(attempt)
+		if b > webhookMaxBackoff {
+			t.Errorf("backoff(%d) = %s exceeds the cap", attempt, b)
+		}
+		total += b
+	}
+	if total < 2*time.Hour || total > 3*time.Hour {
+		t.Errorf("retries span %s, want about 2.4h", total)
+	}
+}